@@ -0,0 +1,362 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package parser
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/ritvikos/synapse/extract/transform"
+)
+
+var ErrRequired = errors.New("extract: required field is missing")
+
+// FieldError is returned when a field cannot be decoded.
+type FieldError struct {
+	Err error
+
+	// Path of the field (eg: "Offers[1].Price")
+	Field string
+
+	// Selector of the field, empty if it's the enclosing element
+	Selector string
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("extract: field %s (selector %q): %v", e.Field, e.Selector, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+var (
+	transformersMu sync.RWMutex
+
+	// Transformers available to the `transform` tag
+	transformers = map[string]transform.Transformer{
+		"trim":       transform.TrimSpace,
+		"lower":      transform.ToLower,
+		"upper":      transform.ToUpper,
+		"digits":     transform.OnlyDigits,
+		"whitespace": transform.NormalizeWhitespace,
+		"entities":   transform.DecodeEntities,
+		"nfc":        transform.NFC,
+		"nfkc":       transform.NFKC,
+		"url":        transform.CleanURL,
+	}
+)
+
+// Registers the transformer for the `transform` tag (eg: `transform:"trim,slug"`),
+// replacing the existing one of the same name.
+func RegisterTransformer(name string, transformer transform.Transformer) {
+	transformersMu.Lock()
+	defer transformersMu.Unlock()
+	transformers[name] = transformer
+}
+
+func lookupTransformer(name string) (transform.Transformer, bool) {
+	transformersMu.RLock()
+	defer transformersMu.RUnlock()
+	transformer, ok := transformers[name]
+	return transformer, ok
+}
+
+// Layouts tried for the time fields without the `layout` tag.
+var defaultTimeLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	time.RFC1123Z,
+	time.RFC1123,
+}
+
+var (
+	timeType            = reflect.TypeFor[time.Time]()
+	durationType        = reflect.TypeFor[time.Duration]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+type fieldTag struct {
+	selector     string
+	attr         string
+	layout       string
+	transformers []transform.Transformer
+	required     bool
+}
+
+func parseFieldTag(field reflect.StructField) (fieldTag, bool, error) {
+	selector, ok := field.Tag.Lookup("css")
+	if !ok {
+		return fieldTag{}, false, nil
+	}
+
+	tag := fieldTag{
+		selector: strings.TrimSpace(selector),
+		attr:     field.Tag.Get("attr"),
+		layout:   field.Tag.Get("layout"),
+	}
+
+	if required := field.Tag.Get("required"); required != "" {
+		var err error
+		if tag.required, err = strconv.ParseBool(required); err != nil {
+			return tag, true, fmt.Errorf("invalid required tag: %w", err)
+		}
+	}
+
+	if names := field.Tag.Get("transform"); names != "" {
+		for name := range strings.SplitSeq(names, ",") {
+			name = strings.TrimSpace(name)
+			transformer, ok := lookupTransformer(name)
+			if !ok {
+				return tag, true, fmt.Errorf("unknown transformer %q", name)
+			}
+			tag.transformers = append(tag.transformers, transformer)
+		}
+	}
+
+	return tag, true, nil
+}
+
+// Populates the struct pointed to by v, as per the tags of its fields:
+//
+//   - `css` selects the elements within the enclosing element, an empty
+//     selector refers to the enclosing element itself
+//   - `attr` reads the attribute instead of the (normalized) text
+//   - `transform` applies the comma-separated transformers (eg: "trim,lower"),
+//     see [RegisterTransformer]
+//   - `layout` parses the time fields, defaults to RFC 3339 and a few common layouts
+//   - `required:"true"` fails with [ErrRequired] when no element (or attribute) matches
+//
+// The fields can be strings, booleans, integers, floats, [time.Time],
+// [time.Duration], [encoding.TextUnmarshaler], pointers (nil when missing),
+// slices (one value per matching element) and nested structs, which are
+// decoded within the first matching element. The nested structs without the
+// `css` tag are decoded within the enclosing element.
+//
+// # Example Usage
+//
+//	type Product struct {
+//		Name   string    `css:"h1.title" transform:"trim" required:"true"`
+//		Price  float64   `css:".price" transform:"digits"`
+//		Tags   []string  `css:".tags li"`
+//		Added  time.Time `css:"time" attr:"datetime" layout:"2006-01-02"`
+//		Images []struct {
+//			URL string `css:"" attr:"src"`
+//			Alt string `css:"" attr:"alt"`
+//		} `css:".gallery img"`
+//	}
+//
+//	var product Product
+//	err := doc.Decode(&product)
+func (e *Element) Decode(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("extract: decode target must be a non-nil pointer to struct, got %T", v)
+	}
+	return decodeStruct(e.dom, rv.Elem(), "")
+}
+
+func decodeStruct(s *goquery.Selection, v reflect.Value, path string) error {
+	t := v.Type()
+	for i := range t.NumField() {
+		field := t.Field(i)
+		// The exported fields of the embedded structs are settable regardless
+		if !field.IsExported() && !(field.Anonymous && field.Type.Kind() == reflect.Struct) {
+			continue
+		}
+
+		name := field.Name
+		if path != "" {
+			name = path + "." + field.Name
+		}
+
+		tag, ok, err := parseFieldTag(field)
+		if err != nil {
+			return &FieldError{Err: err, Field: name, Selector: tag.selector}
+		}
+		if !ok {
+			if !isNestedStruct(field.Type) {
+				continue
+			}
+			// Embedded structs share the path of the enclosing one
+			if field.Anonymous {
+				name = path
+			}
+			if err := decodeStruct(s, v.Field(i), name); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := decodeField(s, v.Field(i), tag, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func decodeField(s *goquery.Selection, v reflect.Value, tag fieldTag, name string) error {
+	selection := s
+	if tag.selector != "" {
+		selection = s.Find(tag.selector)
+	}
+
+	if v.Kind() == reflect.Slice {
+		slice := reflect.MakeSlice(v.Type(), 0, selection.Length())
+		for i := range selection.Length() {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			ok, err := decodeValue(selection.Eq(i), elem, tag, fmt.Sprintf("%s[%d]", name, i))
+			if err != nil {
+				return err
+			}
+			if ok {
+				slice = reflect.Append(slice, elem)
+			}
+		}
+		if tag.required && slice.Len() == 0 {
+			return &FieldError{Err: ErrRequired, Field: name, Selector: tag.selector}
+		}
+		v.Set(slice)
+		return nil
+	}
+
+	if selection.Length() == 0 {
+		if tag.required {
+			return &FieldError{Err: ErrRequired, Field: name, Selector: tag.selector}
+		}
+		return nil
+	}
+
+	ok, err := decodeValue(selection.First(), v, tag, name)
+	if err != nil {
+		return err
+	}
+	if !ok && tag.required {
+		return &FieldError{Err: ErrRequired, Field: name, Selector: tag.selector}
+	}
+	return nil
+}
+
+// Decodes the element into the value, returns false if the attribute is missing.
+func decodeValue(s *goquery.Selection, v reflect.Value, tag fieldTag, name string) (bool, error) {
+	if v.Kind() == reflect.Pointer {
+		ptr := reflect.New(v.Type().Elem())
+		ok, err := decodeValue(s, ptr.Elem(), tag, name)
+		if ok && err == nil {
+			v.Set(ptr)
+		}
+		return ok, err
+	}
+
+	if isNestedStruct(v.Type()) {
+		return true, decodeStruct(s, v, name)
+	}
+
+	var text string
+	if tag.attr != "" {
+		attr, ok := s.Attr(tag.attr)
+		if !ok {
+			return false, nil
+		}
+		text = strings.TrimSpace(attr)
+	} else {
+		text = transform.NormalizeWhitespace(s.Text())
+	}
+	text = transform.ApplyTransformations(text, tag.transformers...)
+
+	if err := setScalar(v, text, tag.layout); err != nil {
+		return true, &FieldError{Err: err, Field: name, Selector: tag.selector}
+	}
+	return true, nil
+}
+
+func setScalar(v reflect.Value, text, layout string) error {
+	// time.Time is a text unmarshaler too, but with the fixed RFC 3339 layout
+	if v.Type() != timeType && v.CanAddr() && v.Addr().Type().Implements(textUnmarshalerType) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(text))
+	}
+
+	if v.Kind() == reflect.String {
+		v.SetString(text)
+		return nil
+	}
+
+	// Leave the zero value, instead of failing to parse an empty text
+	if text == "" {
+		return nil
+	}
+
+	switch v.Type() {
+	case timeType:
+		t, err := parseTime(text, layout)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	case durationType:
+		d, err := time.ParseDuration(text)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(text, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(text, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(text, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported field type %s", v.Type())
+	}
+	return nil
+}
+
+func parseTime(text, layout string) (time.Time, error) {
+	if layout != "" {
+		return time.Parse(layout, text)
+	}
+	for _, layout := range defaultTimeLayouts {
+		if t, err := time.Parse(layout, text); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("cannot parse time %q", text)
+}
+
+func isNestedStruct(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t != timeType && !reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
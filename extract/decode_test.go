@@ -0,0 +1,140 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package parser
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ritvikos/synapse/extract/transform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type decodeImage struct {
+	URL string `css:"" attr:"src"`
+	Alt string `css:"" attr:"alt" transform:"lower"`
+}
+
+type decodeMeta struct {
+	Added   time.Time     `css:"time" attr:"datetime"`
+	Updated *time.Time    `css:".updated" layout:"02/01/2006"`
+	Warmup  time.Duration `css:".warmup"`
+}
+
+type decodeProduct struct {
+	Stock  *int     `css:".stock"`
+	Rating *float64 `css:".rating"`
+	decodeMeta
+	Name    string `css:"h1.title" required:"true"`
+	ignored string
+	Tags    []string      `css:".tags li" transform:"upper"`
+	Images  []decodeImage `css:".gallery img"`
+	Seller  struct {
+		Name string `css:"span"`
+		ID   uint16 `css:"" attr:"data-id"`
+	} `css:".seller"`
+	Price   float64 `css:".price" transform:"digits"`
+	InStock bool    `css:".stock" attr:"data-available"`
+}
+
+func TestDecode(t *testing.T) {
+	page := `<html><body>
+		<h1 class="title">  Trail
+			Shoe </h1>
+		<span class="price">EUR 89.90</span>
+		<span class="stock" data-available="true">12</span>
+		<ul class="tags"><li>running</li><li>outdoor</li></ul>
+		<div class="gallery"><img src="/a.jpg" alt="Front"><img src="/b.jpg" alt="Side"></div>
+		<div class="seller" data-id="42"><span>Acme</span></div>
+		<time datetime="2026-01-02T10:00:00Z">Jan 2</time>
+		<span class="updated">15/03/2026</span>
+		<span class="warmup">1m30s</span>
+	</body></html>`
+
+	doc, err := NewElement(strings.NewReader(page))
+	require.NoError(t, err)
+
+	var product decodeProduct
+	require.NoError(t, doc.Decode(&product))
+
+	assert.Equal(t, "Trail Shoe", product.Name)
+	assert.InDelta(t, 89.90, product.Price, 1e-9)
+	require.NotNil(t, product.Stock)
+	assert.Equal(t, 12, *product.Stock)
+	assert.Nil(t, product.Rating, "missing elements leave pointers nil")
+	assert.True(t, product.InStock)
+	assert.Equal(t, []string{"RUNNING", "OUTDOOR"}, product.Tags)
+	assert.Equal(t, []decodeImage{{URL: "/a.jpg", Alt: "front"}, {URL: "/b.jpg", Alt: "side"}}, product.Images)
+	assert.Equal(t, "Acme", product.Seller.Name)
+	assert.Equal(t, uint16(42), product.Seller.ID)
+	assert.Equal(t, time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC), product.Added)
+	require.NotNil(t, product.Updated)
+	assert.Equal(t, time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC), *product.Updated)
+	assert.Equal(t, 90*time.Second, product.Warmup)
+
+	t.Run("required", func(t *testing.T) {
+		doc, err := NewElement(strings.NewReader(`<html><body><p>Nothing</p></body></html>`))
+		require.NoError(t, err)
+
+		var product decodeProduct
+		err = doc.Decode(&product)
+		require.ErrorIs(t, err, ErrRequired)
+
+		var fieldErr *FieldError
+		require.ErrorAs(t, err, &fieldErr)
+		assert.Equal(t, "Name", fieldErr.Field)
+		assert.Equal(t, "h1.title", fieldErr.Selector)
+	})
+
+	t.Run("conversion error", func(t *testing.T) {
+		doc, err := NewElement(strings.NewReader(`<html><body><ul><li><b>1</b></li><li><b>two</b></li></ul></body></html>`))
+		require.NoError(t, err)
+
+		var list struct {
+			Items []struct {
+				N int `css:"b"`
+			} `css:"li"`
+		}
+		err = doc.Decode(&list)
+
+		var numErr *strconv.NumError
+		require.ErrorAs(t, err, &numErr)
+
+		var fieldErr *FieldError
+		require.ErrorAs(t, err, &fieldErr)
+		assert.Equal(t, "Items[1].N", fieldErr.Field)
+	})
+
+	t.Run("transform library", func(t *testing.T) {
+		doc, err := NewElement(strings.NewReader(`<html><body><p class="price">1.299,00 €</p><a href="HTTPS://Example.com/p?utm_source=x&amp;id=1#top">P</a><span title="Tom &amp;amp; Jerry">x</span></body></html>`))
+		require.NoError(t, err)
+
+		price, err := CollectAs(doc, ".price", transform.ParseCurrency("de"))
+		require.NoError(t, err)
+		assert.Equal(t, transform.Money{Currency: "EUR", Amount: 1299}, price)
+
+		_, err = CollectAs(doc, "a", transform.ParseInt)
+		assert.ErrorIs(t, err, transform.ErrInvalidNumber)
+
+		var link struct {
+			URL   string `css:"a" attr:"href" transform:"url"`
+			Title string `css:"span" attr:"title" transform:"entities"`
+		}
+		require.NoError(t, doc.Decode(&link))
+		assert.Equal(t, "https://example.com/p?id=1", link.URL)
+		assert.Equal(t, "Tom & Jerry", link.Title)
+	})
+
+	t.Run("invalid target", func(t *testing.T) {
+		assert.Error(t, doc.Decode(product))
+
+		var unknown struct {
+			Name string `css:"h1" transform:"nope"`
+		}
+		assert.ErrorContains(t, doc.Decode(&unknown), `unknown transformer "nope"`)
+	})
+}
@@ -66,6 +66,16 @@ func (e *Element) CollectAll(selector string, transformers ...transform.Transfor
 	})
 }
 
+// Converts the (normalized) text of the elements matching the selector via
+// the typed transformer, after applying the transformers.
+//
+// # Example Usage
+//
+//	price, err := parser.CollectAs(doc, ".price", transform.ParseCurrency("de"))
+func CollectAs[T any](e *Element, selector string, typed transform.TypedTransformer[T], transformers ...transform.Transformer) (T, error) {
+	return transform.ApplyTyped(e.Find(selector).Text(), typed, transformers...)
+}
+
 func (e *Element) Iter(selector string) *elementIter {
 	return &elementIter{
 		dom: e.dom.Find(selector),
@@ -0,0 +1,138 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Extracts the items of `<script type="application/ld+json">` blocks within
+// the element, flattening the top-level arrays and `@graph`.
+//
+// The blocks with malformed JSON are skipped, as they're common in the wild
+// and shouldn't invalidate the rest of the page.
+func (e *Element) JSONLD() []*Item {
+	var items []*Item
+
+	e.dom.Find(`script[type]`).Each(func(_ int, s *goquery.Selection) {
+		mediaType, _, _ := strings.Cut(s.AttrOr("type", ""), ";")
+		if !strings.EqualFold(strings.TrimSpace(mediaType), "application/ld+json") {
+			return
+		}
+
+		decoder := json.NewDecoder(strings.NewReader(unwrapScript(s.Text())))
+		decoder.UseNumber()
+
+		var doc any
+		if err := decoder.Decode(&doc); err != nil {
+			return
+		}
+		items = append(items, jsonLDItems(doc)...)
+	})
+
+	return items
+}
+
+// Strips the legacy wrappers of the script contents (eg: "<!-- ... -->", CDATA).
+func unwrapScript(text string) string {
+	text = strings.TrimSpace(text)
+	for _, wrapper := range [][2]string{{"<!--", "-->"}, {"/*<![CDATA[*/", "/*]]>*/"}, {"<![CDATA[", "]]>"}} {
+		if strings.HasPrefix(text, wrapper[0]) && strings.HasSuffix(text, wrapper[1]) {
+			text = strings.TrimSpace(text[len(wrapper[0]) : len(text)-len(wrapper[1])])
+		}
+	}
+	return text
+}
+
+func jsonLDItems(doc any) []*Item {
+	switch v := doc.(type) {
+	case []any:
+		var items []*Item
+		for _, node := range v {
+			items = append(items, jsonLDItems(node)...)
+		}
+		return items
+	case map[string]any:
+		var items []*Item
+		if graph, ok := v["@graph"]; ok {
+			items = jsonLDItems(graph)
+			if _, typed := v["@type"]; !typed {
+				return items
+			}
+		}
+		return append([]*Item{jsonLDItem(v)}, items...)
+	default:
+		return nil
+	}
+}
+
+func jsonLDItem(node map[string]any) *Item {
+	item := newItem(SyntaxJSONLD)
+
+	for key, value := range node {
+		switch key {
+		case "@type":
+			for _, typ := range jsonLDStrings(value) {
+				item.Types = append(item.Types, normalizeTerm(typ))
+			}
+		case "@id":
+			item.ID, _ = value.(string)
+		case "@context", "@graph":
+		default:
+			for _, v := range jsonLDValues(value) {
+				item.add(key, v)
+			}
+		}
+	}
+
+	return item
+}
+
+func jsonLDValues(value any) []Value {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []any:
+		var values []Value
+		for _, elem := range v {
+			values = append(values, jsonLDValues(elem)...)
+		}
+		return values
+	case map[string]any:
+		// Value objects (eg: {"@value": "..", "@language": "en"}) and lists
+		if literal, ok := v["@value"]; ok {
+			return jsonLDValues(literal)
+		}
+		if list, ok := v["@list"]; ok {
+			return jsonLDValues(list)
+		}
+		return []Value{{Item: jsonLDItem(v)}}
+	case string:
+		return []Value{{Text: v}}
+	default:
+		// Numbers and booleans
+		return []Value{{Text: fmt.Sprint(v)}}
+	}
+}
+
+func jsonLDStrings(value any) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		var values []string
+		for _, elem := range v {
+			if s, ok := elem.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
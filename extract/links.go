@@ -0,0 +1,218 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package parser
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/ritvikos/synapse/extract/transform"
+	"github.com/ritvikos/synapse/robots/directives"
+)
+
+// Elements referencing other resources, along with the attribute holding the URL.
+var linkSources = map[string]string{
+	"a":      "href",
+	"area":   "href",
+	"link":   "href",
+	"iframe": "src",
+	"img":    "src",
+	"script": "src",
+}
+
+// Link is an outgoing link of the page.
+type Link struct {
+	// Absolute URL, without fragment (eg: for `Frontier.Enqueue`)
+	URL string
+
+	// Element the link was found in (eg: "a", "img")
+	Tag string
+
+	// Anchor text for `a`, `alt` attribute for `area` and `img`
+	Text string
+
+	// Lowercased tokens of the `rel` attribute (eg: ["nofollow", "ugc"])
+	Rel []string
+
+	// Set when the link, or the whole page (via robots directives), is marked nofollow
+	NoFollow bool
+}
+
+type linkOptions struct {
+	directives      directives.Directives
+	userAgent       string
+	tags            []string
+	schemes         []string
+	includeNoFollow bool
+}
+
+type LinkOption func(*linkOptions)
+
+// Includes the links marked nofollow, instead of skipping them.
+func WithNoFollowLinks() LinkOption {
+	return func(o *linkOptions) {
+		o.includeNoFollow = true
+	}
+}
+
+// User-agent token for the scoped meta robots (eg: `<meta name="synapse">`),
+// in addition to `<meta name="robots">`.
+func WithRobotsUserAgent(userAgent string) LinkOption {
+	return func(o *linkOptions) {
+		o.userAgent = userAgent
+	}
+}
+
+// Directives of the page declared outside the document, merged with the
+// meta robots (eg: from the `X-Robots-Tag` header of the response).
+func WithRobotsDirectives(robots directives.Directives) LinkOption {
+	return func(o *linkOptions) {
+		o.directives = robots
+	}
+}
+
+// Restricts the elements the links are collected from (eg: "a", "area"),
+// defaults to a, area, link, iframe, img and script.
+func WithLinkTags(tags ...string) LinkOption {
+	return func(o *linkOptions) {
+		o.tags = tags
+	}
+}
+
+// Restricts the schemes of the links, defaults to http and https.
+func WithLinkSchemes(schemes ...string) LinkOption {
+	return func(o *linkOptions) {
+		o.schemes = schemes
+	}
+}
+
+// Extracts the outgoing links within the element, resolved against the
+// `<base href>` of the document (if any) and the page URL, which should be
+// the final URL of the fetch (i.e. after redirects).
+//
+// Links are deduplicated by URL (first occurrence wins), and the links to
+// other schemes (eg: "javascript:", "mailto:") are skipped.
+func (e *Element) Links(pageURL string, opts ...LinkOption) ([]Link, error) {
+	options := linkOptions{schemes: []string{"http", "https"}}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	page, err := url.Parse(pageURL)
+	if err != nil {
+		return nil, fmt.Errorf("extract: invalid page url: %w", err)
+	}
+
+	root := e.root()
+	base := resolveBase(root, page)
+	pageNoFollow := options.directives.Merge(e.RobotsDirectives(options.userAgent)).NoFollow
+
+	var selector []string
+	for tag, attr := range linkSources {
+		if len(options.tags) == 0 || slices.Contains(options.tags, tag) {
+			selector = append(selector, tag+"["+attr+"]")
+		}
+	}
+	if len(selector) == 0 {
+		return nil, nil
+	}
+	slices.Sort(selector)
+
+	var links []Link
+	seen := make(map[string]struct{})
+
+	e.dom.Find(strings.Join(selector, ", ")).Each(func(_ int, s *goquery.Selection) {
+		tag := goquery.NodeName(s)
+		raw, _ := s.Attr(linkSources[tag])
+
+		target, ok := resolveLink(base, raw, options.schemes)
+		if !ok {
+			return
+		}
+
+		rel := strings.Fields(strings.ToLower(s.AttrOr("rel", "")))
+		noFollow := pageNoFollow || slices.Contains(rel, "nofollow")
+		if noFollow && !options.includeNoFollow {
+			return
+		}
+
+		if _, ok := seen[target]; ok {
+			return
+		}
+		seen[target] = struct{}{}
+
+		links = append(links, Link{
+			URL:      target,
+			Tag:      tag,
+			Text:     linkText(s, tag),
+			Rel:      rel,
+			NoFollow: noFollow,
+		})
+	})
+
+	return links, nil
+}
+
+// Root of the document the element belongs to.
+func (e *Element) root() *goquery.Selection {
+	if len(e.dom.Nodes) == 0 {
+		return e.dom
+	}
+	node := e.dom.Nodes[0]
+	for node.Parent != nil {
+		node = node.Parent
+	}
+	return goquery.NewDocumentFromNode(node).Selection
+}
+
+// Resolves the first `<base href>` against the page URL (HTML spec, section 4.2.3).
+func resolveBase(root *goquery.Selection, page *url.URL) *url.URL {
+	href, ok := root.Find("base[href]").First().Attr("href")
+	if !ok {
+		return page
+	}
+	base, err := page.Parse(strings.TrimSpace(href))
+	if err != nil {
+		return page
+	}
+	return base
+}
+
+func resolveLink(base *url.URL, raw string, schemes []string) (string, bool) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" || strings.HasPrefix(raw, "#") {
+		return "", false
+	}
+
+	target, err := base.Parse(raw)
+	if err != nil {
+		return "", false
+	}
+	if !slices.Contains(schemes, target.Scheme) {
+		return "", false
+	}
+
+	target.Fragment = ""
+	target.RawFragment = ""
+	return target.String(), true
+}
+
+func linkText(s *goquery.Selection, tag string) string {
+	switch tag {
+	case "a":
+		text := transform.NormalizeWhitespace(s.Text())
+		if text == "" {
+			// Image links
+			text = transform.NormalizeWhitespace(s.Find("img[alt]").First().AttrOr("alt", ""))
+		}
+		return text
+	case "area", "img":
+		return transform.NormalizeWhitespace(s.AttrOr("alt", ""))
+	default:
+		return ""
+	}
+}
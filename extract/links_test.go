@@ -0,0 +1,117 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ritvikos/synapse/robots/directives"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinks(t *testing.T) {
+	page := `<html><head>
+		<base href="/docs/">
+		<link rel="stylesheet" href="style.css">
+		<script src="https://cdn.example.net/app.js"></script>
+	</head><body>
+		<nav>
+			<a href="intro.html#setup">  Getting
+				started </a>
+			<a href="../about">About</a>
+			<a href="https://ads.example.net/" rel="sponsored NoFollow">Ad</a>
+			<a href="intro.html">Duplicate</a>
+			<a href="mailto:team@example.com">Mail</a>
+			<a href="javascript:void(0)">Menu</a>
+			<a href="#top">Top</a>
+		</nav>
+		<map><area href="/map/north" alt="North"></map>
+		<a href="//example.org/logo"><img src="logo.png" alt="Logo"></a>
+		<iframe src="embed.html"></iframe>
+	</body></html>`
+
+	doc, err := NewElement(strings.NewReader(page))
+	require.NoError(t, err)
+
+	links, err := doc.Links("https://example.com/blog/post?id=1")
+	require.NoError(t, err)
+
+	urls := make([]string, 0, len(links))
+	for _, link := range links {
+		urls = append(urls, link.URL)
+	}
+	assert.Equal(t, []string{
+		"https://example.com/docs/style.css",
+		"https://cdn.example.net/app.js",
+		"https://example.com/docs/intro.html",
+		"https://example.com/about",
+		"https://example.com/map/north",
+		"https://example.org/logo",
+		"https://example.com/docs/logo.png",
+		"https://example.com/docs/embed.html",
+	}, urls)
+
+	assert.Equal(t, Link{URL: "https://example.com/docs/style.css", Tag: "link", Rel: []string{"stylesheet"}}, links[0])
+	assert.Equal(t, "Getting started", links[2].Text)
+	assert.Equal(t, "North", links[4].Text)
+	assert.Equal(t, "Logo", links[5].Text, "image links use the alt text")
+
+	t.Run("nofollow", func(t *testing.T) {
+		links, err := doc.Links("https://example.com/", WithNoFollowLinks(), WithLinkTags("a"))
+		require.NoError(t, err)
+
+		var noFollow []Link
+		for _, link := range links {
+			if link.NoFollow {
+				noFollow = append(noFollow, link)
+			}
+		}
+		require.Len(t, noFollow, 1)
+		assert.Equal(t, "https://ads.example.net/", noFollow[0].URL)
+		assert.Equal(t, []string{"sponsored", "nofollow"}, noFollow[0].Rel)
+	})
+
+	t.Run("meta robots nofollow", func(t *testing.T) {
+		doc, err := NewElement(strings.NewReader(`<html><head><meta name="ROBOTS" content="noindex, nofollow"></head><body><a href="/next">Next</a></body></html>`))
+		require.NoError(t, err)
+
+		links, err := doc.Links("https://example.com/")
+		require.NoError(t, err)
+		assert.Empty(t, links)
+
+		links, err = doc.Links("https://example.com/", WithNoFollowLinks())
+		require.NoError(t, err)
+		require.Len(t, links, 1)
+		assert.True(t, links[0].NoFollow)
+	})
+
+	t.Run("scoped robots directives", func(t *testing.T) {
+		doc, err := NewElement(strings.NewReader(`<html><head><meta name="synapse" content="nofollow"><meta name="otherbot" content="none"></head><body><a href="/next">Next</a></body></html>`))
+		require.NoError(t, err)
+
+		links, err := doc.Links("https://example.com/")
+		require.NoError(t, err)
+		assert.Len(t, links, 1, "meta for other user-agents is ignored")
+
+		links, err = doc.Links("https://example.com/", WithRobotsUserAgent("synapse"))
+		require.NoError(t, err)
+		assert.Empty(t, links)
+
+		header := directives.ParseXRobotsTag([]string{"otherbot: nofollow", "nofollow"}, "synapse")
+		links, err = doc.Links("https://example.com/", WithRobotsDirectives(header))
+		require.NoError(t, err)
+		assert.Empty(t, links)
+
+		assert.Equal(t, directives.Directives{NoIndex: true, NoFollow: true}, doc.RobotsDirectives("otherbot"))
+	})
+
+	t.Run("within element", func(t *testing.T) {
+		links, err := doc.Find("nav").Links("https://example.com/blog/")
+		require.NoError(t, err)
+		require.Len(t, links, 2)
+		assert.Equal(t, "https://example.com/docs/intro.html", links[0].URL, "base href applies to the whole document")
+	})
+}
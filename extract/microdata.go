@@ -0,0 +1,197 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package parser
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+// Extracts the top-level Microdata items (`itemscope` without `itemprop`)
+// within the element, including their `itemref` properties.
+func (e *Element) Microdata() []*Item {
+	var items []*Item
+
+	root := e.root()
+	scopes(e.dom, "[itemscope]").Each(func(_ int, s *goquery.Selection) {
+		if _, ok := s.Attr("itemprop"); ok {
+			return
+		}
+		items = append(items, microdataItem(s, root, make(map[*html.Node]struct{})))
+	})
+
+	return items
+}
+
+func microdataItem(s, root *goquery.Selection, visiting map[*html.Node]struct{}) *Item {
+	node := s.Nodes[0]
+	visiting[node] = struct{}{}
+	defer delete(visiting, node)
+
+	item := newItem(SyntaxMicrodata)
+	item.ID = strings.TrimSpace(s.AttrOr("itemid", ""))
+	for _, typ := range strings.Fields(s.AttrOr("itemtype", "")) {
+		item.Types = append(item.Types, normalizeTerm(typ))
+	}
+
+	var walk func(*goquery.Selection)
+	walk = func(elements *goquery.Selection) {
+		elements.Each(func(_ int, c *goquery.Selection) {
+			if properties := strings.Fields(c.AttrOr("itemprop", "")); len(properties) > 0 {
+				value, ok := microdataValue(c, root, visiting)
+				if ok {
+					for _, property := range properties {
+						item.add(property, value)
+					}
+				}
+			}
+			// The properties of the nested items belong to them
+			if _, ok := c.Attr("itemscope"); !ok {
+				walk(c.Children())
+			}
+		})
+	}
+	walk(s.Children())
+
+	for _, id := range strings.Fields(s.AttrOr("itemref", "")) {
+		walk(root.Find("[id]").FilterFunction(func(_ int, ref *goquery.Selection) bool {
+			return ref.AttrOr("id", "") == id
+		}).First())
+	}
+
+	return item
+}
+
+func microdataValue(s, root *goquery.Selection, visiting map[*html.Node]struct{}) (Value, bool) {
+	if _, ok := s.Attr("itemscope"); ok {
+		// Cyclic references via itemref
+		if _, ok := visiting[s.Nodes[0]]; ok {
+			return Value{}, false
+		}
+		return Value{Item: microdataItem(s, root, visiting)}, true
+	}
+
+	attr := ""
+	switch goquery.NodeName(s) {
+	case "meta":
+		attr = "content"
+	case "audio", "embed", "iframe", "img", "source", "track", "video":
+		attr = "src"
+	case "a", "area", "link":
+		attr = "href"
+	case "object":
+		attr = "data"
+	case "data", "meter":
+		attr = "value"
+	case "time":
+		if value, ok := s.Attr("datetime"); ok {
+			return Value{Text: strings.TrimSpace(value)}, true
+		}
+	}
+
+	if attr != "" {
+		return Value{Text: strings.TrimSpace(s.AttrOr(attr, ""))}, true
+	}
+	return Value{Text: elementText(s)}, true
+}
+
+// Extracts the RDFa Lite items (`typeof`) within the element, resolving the
+// terms against the `vocab` and `prefix` in scope. The items which are
+// properties of another item are nested within it instead.
+func (e *Element) RDFa() []*Item {
+	var items []*Item
+
+	scopes(e.dom, "[typeof]").Each(func(_ int, s *goquery.Selection) {
+		if _, ok := s.Attr("property"); ok && s.ParentsFiltered("[typeof]").Length() > 0 {
+			return
+		}
+		items = append(items, rdfaItem(s))
+	})
+
+	return items
+}
+
+func rdfaItem(s *goquery.Selection) *Item {
+	item := newItem(SyntaxRDFa)
+	item.ID = strings.TrimSpace(s.AttrOr("resource", ""))
+	for _, typ := range strings.Fields(s.AttrOr("typeof", "")) {
+		item.Types = append(item.Types, resolveRDFaTerm(s, typ))
+	}
+
+	var walk func(*goquery.Selection)
+	walk = func(elements *goquery.Selection) {
+		elements.Each(func(_ int, c *goquery.Selection) {
+			_, typed := c.Attr("typeof")
+			for _, property := range strings.Fields(c.AttrOr("property", "")) {
+				item.add(resolveRDFaTerm(c, property), rdfaValue(c, typed))
+			}
+			if !typed {
+				walk(c.Children())
+			}
+		})
+	}
+	walk(s.Children())
+
+	return item
+}
+
+func rdfaValue(s *goquery.Selection, typed bool) Value {
+	if typed {
+		return Value{Item: rdfaItem(s)}
+	}
+	for _, attr := range []string{"content", "resource", "href", "src", "datetime"} {
+		if value, ok := s.Attr(attr); ok {
+			return Value{Text: strings.TrimSpace(value)}
+		}
+	}
+	return Value{Text: elementText(s)}
+}
+
+// Expands the term to an IRI via the prefixes or the vocabulary in scope.
+func resolveRDFaTerm(s *goquery.Selection, term string) string {
+	if strings.Contains(term, "://") {
+		return normalizeTerm(term)
+	}
+
+	if prefix, name, ok := strings.Cut(term, ":"); ok {
+		if iri, ok := rdfaPrefixes(s)[prefix]; ok {
+			return normalizeTerm(iri + name)
+		}
+		return normalizeTerm(term)
+	}
+
+	if vocab := s.Closest("[vocab]").AttrOr("vocab", ""); vocab != "" {
+		return normalizeTerm(vocab + term)
+	}
+	return term
+}
+
+// Prefix mappings in scope of the element, the nearest ones win.
+func rdfaPrefixes(s *goquery.Selection) map[string]string {
+	prefixes := make(map[string]string)
+
+	declare := func(declaration string) {
+		fields := strings.Fields(declaration)
+		for i := 0; i+1 < len(fields); i += 2 {
+			prefix := strings.TrimSuffix(fields[i], ":")
+			if _, ok := prefixes[prefix]; !ok {
+				prefixes[prefix] = fields[i+1]
+			}
+		}
+	}
+
+	declare(s.AttrOr("prefix", ""))
+	s.ParentsFiltered("[prefix]").Each(func(_ int, p *goquery.Selection) {
+		declare(p.AttrOr("prefix", ""))
+	})
+
+	return prefixes
+}
+
+// Element itself (if matching) and its descendants matching the selector.
+func scopes(s *goquery.Selection, selector string) *goquery.Selection {
+	return s.Filter(selector).AddSelection(s.Find(selector))
+}
@@ -0,0 +1,185 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package parser
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/ritvikos/synapse/extract/transform"
+	"golang.org/x/net/html"
+)
+
+// Number of the nodes in the element (eg: the matches of Find).
+func (e *Element) Length() int {
+	return e.dom.Length()
+}
+
+func (e *Element) First() *Element {
+	return newElementFromSelection(e.dom.First())
+}
+
+func (e *Element) Last() *Element {
+	return newElementFromSelection(e.dom.Last())
+}
+
+// Node at the index, negative indices count from the end (eg: -1 is the last).
+// Empty if out of range.
+func (e *Element) Eq(index int) *Element {
+	return newElementFromSelection(e.dom.Eq(index))
+}
+
+func (e *Element) Parent() *Element {
+	return newElementFromSelection(e.dom.Parent())
+}
+
+// Element children, without the text and comment nodes.
+func (e *Element) Children() *Element {
+	return newElementFromSelection(e.dom.Children())
+}
+
+// Following element sibling.
+func (e *Element) Next() *Element {
+	return newElementFromSelection(e.dom.Next())
+}
+
+// Preceding element sibling.
+func (e *Element) Prev() *Element {
+	return newElementFromSelection(e.dom.Prev())
+}
+
+func (e *Element) NextAll() *Element {
+	return newElementFromSelection(e.dom.NextAll())
+}
+
+func (e *Element) PrevAll() *Element {
+	return newElementFromSelection(e.dom.PrevAll())
+}
+
+func (e *Element) Siblings() *Element {
+	return newElementFromSelection(e.dom.Siblings())
+}
+
+// Nearest ancestor matching the selector, including the element itself.
+func (e *Element) Closest(selector string) *Element {
+	return newElementFromSelection(e.dom.Closest(selector))
+}
+
+// Nodes of the element matching the selector.
+func (e *Element) Filter(selector string) *Element {
+	return newElementFromSelection(e.dom.Filter(selector))
+}
+
+// Nodes of the element not matching the selector.
+func (e *Element) Not(selector string) *Element {
+	return newElementFromSelection(e.dom.Not(selector))
+}
+
+// Whether any node of the element matches the selector.
+func (e *Element) Is(selector string) bool {
+	return e.dom.Is(selector)
+}
+
+// HTML of the contents of the first node.
+func (e *Element) Html() string {
+	if len(e.dom.Nodes) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	for child := e.dom.Nodes[0].FirstChild; child != nil; child = child.NextSibling {
+		// Writing to the builder cannot fail
+		_ = html.Render(&b, child)
+	}
+	return b.String()
+}
+
+// HTML of the first node, including itself.
+func (e *Element) OuterHtml() string {
+	if len(e.dom.Nodes) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	_ = html.Render(&b, e.dom.Nodes[0])
+	return b.String()
+}
+
+// Attributes of the first node, in the document order.
+func (e *Element) Attrs() []html.Attribute {
+	if len(e.dom.Nodes) == 0 {
+		return nil
+	}
+	return append([]html.Attribute(nil), e.dom.Nodes[0].Attr...)
+}
+
+// Value of the attribute of the first node with the transformations applied,
+// or empty if missing.
+func (e *Element) AttrValue(attr string, transformers ...transform.Transformer) string {
+	value, ok := e.dom.Attr(attr)
+	if !ok {
+		return ""
+	}
+	return transform.ApplyTransformations(value, transformers...)
+}
+
+// Iterates over the nodes of the element itself (eg: the children).
+func (e *Element) Nodes() *elementIter {
+	return &elementIter{
+		dom: e.dom,
+	}
+}
+
+func (e *elementIter) Length() int {
+	if e.dom == nil {
+		return 0
+	}
+	return e.dom.Length()
+}
+
+func (e *elementIter) First() *Element {
+	return e.Eq(0)
+}
+
+func (e *elementIter) Last() *Element {
+	return e.Eq(-1)
+}
+
+// Element at the index, negative indices count from the end.
+// Empty if out of range.
+func (e *elementIter) Eq(index int) *Element {
+	if e.dom == nil {
+		return newElementFromSelection(&goquery.Selection{})
+	}
+	return newElementFromSelection(e.dom.Eq(index))
+}
+
+// Elements matching the selector.
+func (e *elementIter) Filter(selector string) *elementIter {
+	if e.dom == nil {
+		return e
+	}
+	return &elementIter{
+		dom: e.dom.Filter(selector),
+	}
+}
+
+// Normalized text of each element with the transformations applied.
+func (e *elementIter) Texts(transformers ...transform.Transformer) []string {
+	return e.Map(func(el *Element) string {
+		return transform.ApplyTransformations(el.Text(), transformers...)
+	})
+}
+
+// Values of the attribute with the transformations applied, the elements
+// without the attribute are skipped.
+func (e *elementIter) AttrValues(attr string, transformers ...transform.Transformer) []string {
+	values := []string{}
+	e.Each(func(el *Element) {
+		if value, ok := el.Attr(attr); ok {
+			values = append(values, transform.ApplyTransformations(value, transformers...))
+		}
+	})
+	return values
+}
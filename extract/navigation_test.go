@@ -0,0 +1,82 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ritvikos/synapse/extract/transform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/html"
+)
+
+func TestNavigation(t *testing.T) {
+	page := `<html><body>
+		<ul id="menu" class="nav" data-role="menu">
+			<li class="item">Home</li>
+			<li class="item active"><a href="/docs">Docs</a></li>
+			<li class="item">Blog</li>
+			<li class="item"><a href="/about">About <b>us</b></a></li>
+		</ul>
+	</body></html>`
+
+	doc, err := NewElement(strings.NewReader(page))
+	require.NoError(t, err)
+
+	items := doc.Find("li")
+	assert.Equal(t, 4, items.Length())
+	assert.Equal(t, "Home", items.First().Text())
+	assert.Equal(t, "About us", items.Last().Text())
+	assert.Equal(t, "Blog", items.Eq(2).Text())
+	assert.Equal(t, "Blog", items.Eq(-2).Text())
+	assert.Zero(t, items.Eq(10).Length())
+
+	t.Run("traversal", func(t *testing.T) {
+		active := doc.Find("li.active")
+		assert.Equal(t, "ul", active.Parent().TagName())
+		assert.Equal(t, "Blog", active.Next().Text())
+		assert.Equal(t, "Home", active.Prev().Text())
+		assert.Equal(t, 2, active.NextAll().Length())
+		assert.Equal(t, 1, active.PrevAll().Length())
+		assert.Equal(t, 3, active.Siblings().Length())
+		assert.Equal(t, 4, doc.Find("#menu").Children().Length())
+
+		link := doc.Find("b").Closest("a")
+		assert.Equal(t, "/about", link.AttrValue("href"))
+		assert.Equal(t, "menu", link.Closest("[data-role]").AttrValue("data-role", transform.ToUpper, transform.ToLower))
+
+		assert.Equal(t, 2, items.Filter(":has(a)").Length())
+		assert.Equal(t, "Home", items.Not(":has(a)").First().Text())
+		assert.True(t, items.Is(".active"))
+		assert.False(t, items.Is("a"))
+	})
+
+	t.Run("serialization", func(t *testing.T) {
+		last := items.Last()
+		assert.Equal(t, `<a href="/about">About <b>us</b></a>`, last.Html())
+		assert.Equal(t, `<li class="item"><a href="/about">About <b>us</b></a></li>`, last.OuterHtml())
+		assert.Empty(t, doc.Find("table").Html())
+
+		assert.Equal(t, []html.Attribute{
+			{Key: "id", Val: "menu"},
+			{Key: "class", Val: "nav"},
+			{Key: "data-role", Val: "menu"},
+		}, doc.Find("ul").Attrs())
+	})
+
+	t.Run("iterator", func(t *testing.T) {
+		iter := doc.Iter("li")
+		assert.Equal(t, 4, iter.Length())
+		assert.Equal(t, "Home", iter.First().Text())
+		assert.Equal(t, "About us", iter.Last().Text())
+		assert.Equal(t, "Docs", iter.Eq(1).Text())
+		assert.Equal(t, []string{"DOCS", "ABOUT US"}, iter.Filter(":has(a)").Texts(transform.ToUpper))
+		assert.Equal(t, []string{"docs", "about"}, doc.Iter("a").AttrValues("href", func(s string) string {
+			return strings.TrimPrefix(s, "/")
+		}))
+		assert.Equal(t, []string{"Home", "Docs", "Blog", "About us"}, doc.Find("#menu").Children().Nodes().Texts())
+	})
+}
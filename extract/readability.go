@@ -0,0 +1,409 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package parser
+
+import (
+	"errors"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/ritvikos/synapse/extract/transform"
+	"golang.org/x/net/html"
+)
+
+var ErrNoContent = errors.New("extract: no main content found")
+
+var (
+	// Class and id hints of the boilerplate blocks
+	unlikelyCandidates = regexp.MustCompile(`(?i)-ad-|ad-break|adbox|advert|banner|breadcrumb|combx|comment|community|cookie|disqus|extra|footer|gdpr|header|legends|menu|modal|nav|newsletter|pager|pagination|popup|promo|related|remark|replies|rss|share|shoutbox|sidebar|skyscraper|social|sponsor|subscribe|tags|tool|widget`)
+	maybeCandidates    = regexp.MustCompile(`(?i)and|article|body|column|content|main|shadow|story`)
+
+	positiveHints = regexp.MustCompile(`(?i)article|body|content|entry|hentry|h-entry|main|page|post|text|blog|story`)
+	negativeHints = regexp.MustCompile(`(?i)-ad-|hidden|^hid$| hid$| hid |^hid |banner|combx|comment|com-|contact|foot|footer|footnote|gdpr|masthead|media|meta|outbrain|promo|related|scroll|share|shoutbox|sidebar|skyscraper|sponsor|shopping|tags|tool|widget`)
+
+	bylinePrefix = regexp.MustCompile(`(?i)^\s*(by|written by|posted by|author:)\s+`)
+	titleSuffix  = regexp.MustCompile(`\s+[|\-–—»:]\s+[^|\-–—»:]+$`)
+)
+
+// Elements never part of the main content.
+const boilerplateSelector = "script, style, noscript, template, iframe, button, input, select, textarea, svg, canvas, nav, aside, footer, dialog"
+
+// Form controls the users interact with, without the hidden inputs.
+const controlSelector = "button, input:not([type=hidden]), select, textarea"
+
+// Elements whose text is scored as a paragraph.
+const scoredSelector = "p, pre, td, blockquote, section, h2, h3, h4, h5, h6, div"
+
+// Minimum characters of a block to be scored.
+const minScoredLength = 25
+
+// Article is the main content of the page, along with its metadata.
+type Article struct {
+	// Publish date, zero if not found
+	Published time.Time
+
+	// Main content, detached from the document (i.e. a cleaned copy)
+	Content *Element
+
+	Title string
+
+	// Author of the article (eg: "Jane Doe"), without the "By" prefix
+	Byline string
+
+	// Text of the main content, see [Element.PlainText]
+	Text string
+}
+
+// Extracts the main content of the page (eg: the article body, without the
+// navigation, sidebars, comments and ads), by scoring the blocks by their
+// text and link density, along with the title, byline and publish date from
+// the metadata (JSON-LD, OpenGraph, meta) or the content.
+//
+// The document isn't modified, the content is extracted from a copy.
+func (e *Element) Article() (*Article, error) {
+	root := e.root()
+	data := e.StructuredData()
+
+	content := e.dom.Clone()
+	forms := boilerplateForms(content)
+	removeBoilerplate(content)
+
+	top := topCandidate(content)
+	if top == nil {
+		return nil, ErrNoContent
+	}
+	removeForms(forms, top.Nodes[0])
+
+	body := mergeSiblings(top)
+	removeBylines(body)
+
+	article := &Article{Content: newElementFromSelection(body)}
+	article.Text = article.Content.PlainText()
+	if article.Text == "" {
+		return nil, ErrNoContent
+	}
+
+	article.Title = articleTitle(root, data)
+	article.Byline = articleByline(root, data)
+	article.Published = articlePublished(root, data)
+
+	return article, nil
+}
+
+func removeBoilerplate(s *goquery.Selection) {
+	s.Find(boilerplateSelector).Remove()
+	s.Find("[hidden], [aria-hidden=true], [style*='display:none'], [style*='display: none']").Remove()
+
+	s.Find("*").Each(func(_ int, el *goquery.Selection) {
+		switch goquery.NodeName(el) {
+		case "html", "body", "article", "main", "a":
+			return
+		}
+		hints := el.AttrOr("class", "") + " " + el.AttrOr("id", "")
+		if hints == " " {
+			return
+		}
+		if unlikelyCandidates.MatchString(hints) && !maybeCandidates.MatchString(hints) {
+			el.Remove()
+		}
+	})
+}
+
+// Forms holding the controls or little text (eg: the search boxes, sign-ups).
+// The whole page is wrapped in a form at times (eg: ASP.NET), hence they're
+// found before the controls are removed, but removed after the scoring.
+func boilerplateForms(s *goquery.Selection) []*html.Node {
+	var forms []*html.Node
+	s.Find("form").Each(func(_ int, form *goquery.Selection) {
+		controls := form.Find(controlSelector).Length()
+		paragraphs := form.Find("p").Length()
+		if controls > paragraphs/3 || textLength(form) < minScoredLength || linkDensity(form) > 0.5 {
+			forms = append(forms, form.Nodes[0])
+		}
+	})
+	return forms
+}
+
+// Removes the forms, except the ones containing the top candidate.
+func removeForms(forms []*html.Node, top *html.Node) {
+	for _, form := range forms {
+		if form.Parent != nil && !contains(form, top) {
+			form.Parent.RemoveChild(form)
+		}
+	}
+}
+
+func contains(ancestor, node *html.Node) bool {
+	for ; node != nil; node = node.Parent {
+		if node == ancestor {
+			return true
+		}
+	}
+	return false
+}
+
+// Scores the candidates by the paragraphs within them, returns the best one.
+func topCandidate(s *goquery.Selection) *goquery.Selection {
+	scores := make(map[*html.Node]float64)
+	var order []*html.Node
+
+	score := func(node *html.Node, points float64) {
+		if node == nil || node.Type != html.ElementNode {
+			return
+		}
+		if _, ok := scores[node]; !ok {
+			scores[node] = initialScore(node)
+			order = append(order, node)
+		}
+		scores[node] += points
+	}
+
+	s.Find(scoredSelector).Each(func(_ int, el *goquery.Selection) {
+		// The divs are scored only when they're used as paragraphs
+		if goquery.NodeName(el) == "div" && el.Children().Filter("p, div, pre, blockquote, table, ul, ol, section").Length() > 0 {
+			return
+		}
+
+		text := transform.NormalizeWhitespace(el.Text())
+		length := utf8.RuneCountInString(text)
+		if length < minScoredLength {
+			return
+		}
+
+		points := 1 + float64(strings.Count(text, ",")) + math.Min(float64(length)/100, 3)
+
+		node := el.Nodes[0]
+		score(node.Parent, points)
+		if node.Parent != nil {
+			score(node.Parent.Parent, points/2)
+		}
+	})
+
+	var best *html.Node
+	bestScore := 0.0
+	for _, node := range order {
+		final := scores[node] * (1 - linkDensity(selectionOf(node)))
+		scores[node] = final
+		if final > bestScore {
+			best, bestScore = node, final
+		}
+	}
+	if best == nil {
+		return nil
+	}
+
+	// The paragraphs are often split across the siblings, in which case the
+	// parent is the better candidate (as long as it isn't much worse).
+	for best.Parent != nil && best.Parent.Type == html.ElementNode {
+		parent := best.Parent
+		if parent.Data == "body" || scores[parent] < bestScore*0.75 {
+			break
+		}
+		best = parent
+	}
+
+	return selectionOf(best)
+}
+
+// Appends the siblings of the candidate which look like the content as well.
+func mergeSiblings(top *goquery.Selection) *goquery.Selection {
+	node := top.Nodes[0]
+	if node.Parent == nil {
+		return top
+	}
+
+	threshold := math.Max(minScoredLength*4, float64(textLength(top))*0.2)
+	className := top.AttrOr("class", "")
+
+	wrapper := &html.Node{Type: html.ElementNode, Data: "div"}
+	for sibling := node.Parent.FirstChild; sibling != nil; {
+		next := sibling.NextSibling
+		if sibling.Type != html.ElementNode {
+			sibling = next
+			continue
+		}
+
+		s := selectionOf(sibling)
+		include := sibling == node
+		if !include {
+			length := textLength(s)
+			density := linkDensity(s)
+			switch {
+			case className != "" && s.AttrOr("class", "") == className && length > minScoredLength:
+				include = true
+			case float64(length) >= threshold && density < 0.25:
+				include = true
+			case sibling.Data == "p" && length > 80 && density < 0.25:
+				include = true
+			}
+		}
+
+		if include {
+			node.Parent.RemoveChild(sibling)
+			wrapper.AppendChild(sibling)
+		}
+		sibling = next
+	}
+
+	return selectionOf(wrapper)
+}
+
+func initialScore(node *html.Node) float64 {
+	score := 0.0
+	switch node.Data {
+	case "article", "main":
+		score += 10
+	case "div":
+		score += 5
+	case "pre", "td", "blockquote":
+		score += 3
+	case "address", "ol", "ul", "dl", "dd", "dt", "li", "form":
+		score -= 3
+	case "h1", "h2", "h3", "h4", "h5", "h6", "th":
+		score -= 5
+	}
+
+	s := selectionOf(node)
+	for _, hints := range []string{s.AttrOr("class", ""), s.AttrOr("id", "")} {
+		if hints == "" {
+			continue
+		}
+		if negativeHints.MatchString(hints) {
+			score -= 25
+		}
+		if positiveHints.MatchString(hints) {
+			score += 25
+		}
+	}
+	return score
+}
+
+// Elements carrying the byline within the content.
+const bylineSelector = `[rel="author"], [itemprop="author"], .byline, .author, .byline-name`
+
+// Removes the bylines from the content, as they're returned separately.
+func removeBylines(s *goquery.Selection) {
+	s.Find(bylineSelector).FilterFunction(func(_ int, byline *goquery.Selection) bool {
+		return textLength(byline) < 100
+	}).Remove()
+}
+
+// Ratio of the link text to all the text of the element.
+func linkDensity(s *goquery.Selection) float64 {
+	length := textLength(s)
+	if length == 0 {
+		return 0
+	}
+
+	links := 0
+	s.Find("a").Each(func(_ int, a *goquery.Selection) {
+		links += textLength(a)
+	})
+	return float64(links) / float64(length)
+}
+
+func textLength(s *goquery.Selection) int {
+	return utf8.RuneCountInString(transform.NormalizeWhitespace(s.Text()))
+}
+
+func selectionOf(node *html.Node) *goquery.Selection {
+	return goquery.NewDocumentFromNode(node).Selection
+}
+
+// Types of the JSON-LD items describing the article.
+var articleTypes = []string{"Article", "NewsArticle", "BlogPosting", "Report", "ScholarlyArticle", "TechArticle", "WebPage"}
+
+func articleItem(data *StructuredData) *Item {
+	for _, typ := range articleTypes {
+		if items := data.Find(typ); len(items) > 0 {
+			return items[0]
+		}
+	}
+	return nil
+}
+
+func articleTitle(root *goquery.Selection, data *StructuredData) string {
+	if item := articleItem(data); item != nil {
+		if title := firstNonEmpty(item.Get("headline"), item.Get("name")); title != "" {
+			return transform.NormalizeWhitespace(title)
+		}
+	}
+	if data.OpenGraph.Title != "" {
+		return transform.NormalizeWhitespace(data.OpenGraph.Title)
+	}
+
+	title := transform.NormalizeWhitespace(root.Find("title").First().Text())
+	h1 := transform.NormalizeWhitespace(root.Find("h1").First().Text())
+
+	// The title usually carries the site name as well (eg: "Title | Site")
+	if stripped := titleSuffix.ReplaceAllString(title, ""); stripped != title && len(strings.Fields(stripped)) >= 3 {
+		title = stripped
+	}
+	if title == "" || (h1 != "" && strings.Contains(title, h1)) {
+		return firstNonEmpty(h1, title)
+	}
+	return title
+}
+
+func articleByline(root *goquery.Selection, data *StructuredData) string {
+	byline := ""
+	if item := articleItem(data); item != nil {
+		byline = item.Get("author")
+	}
+	if byline == "" {
+		byline = root.Find(`meta[name="author"], meta[property="article:author"], meta[name="byl"]`).First().AttrOr("content", "")
+		// The article:author is often a profile URL
+		if strings.Contains(byline, "://") {
+			byline = ""
+		}
+	}
+	if byline == "" {
+		byline = root.Find(bylineSelector).First().Text()
+	}
+
+	byline = transform.NormalizeWhitespace(byline)
+	return bylinePrefix.ReplaceAllString(byline, "")
+}
+
+var publishedMeta = []string{
+	`meta[property="article:published_time"]`,
+	`meta[name="article:published_time"]`,
+	`meta[itemprop="datePublished"]`,
+	`meta[name="date"]`,
+	`meta[name="pubdate"]`,
+	`meta[name="publish-date"]`,
+	`meta[name="dc.date.issued"]`,
+	`meta[name="DC.date.issued"]`,
+}
+
+// Parses the publish dates the same as [transform.ParseDate], the ambiguous
+// numeric day/month dates (eg: "03/04/2026") are skipped.
+var parsePublished = transform.ParseDate()
+
+func articlePublished(root *goquery.Selection, data *StructuredData) time.Time {
+	var candidates []string
+	if item := articleItem(data); item != nil {
+		candidates = append(candidates, item.Get("datePublished"), item.Get("dateCreated"))
+	}
+	for _, selector := range publishedMeta {
+		candidates = append(candidates, root.Find(selector).First().AttrOr("content", ""))
+	}
+	candidates = append(candidates, root.Find("time[datetime]").First().AttrOr("datetime", ""))
+	candidates = append(candidates, root.Find("time").First().Text())
+
+	for _, candidate := range candidates {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "" {
+			continue
+		}
+		if t, err := parsePublished(candidate); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
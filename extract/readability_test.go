@@ -0,0 +1,133 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package parser
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Expected extraction of a saved page, next to it in testdata/readability.
+type articleFixture struct {
+	Title     string   `json:"title"`
+	Byline    string   `json:"byline"`
+	Published string   `json:"published"`
+	Contains  []string `json:"contains"`
+	Excludes  []string `json:"excludes"`
+}
+
+func TestArticle(t *testing.T) {
+	pages, err := filepath.Glob(filepath.Join("testdata", "readability", "*.html"))
+	require.NoError(t, err)
+	require.NotEmpty(t, pages)
+
+	for _, page := range pages {
+		name := strings.TrimSuffix(filepath.Base(page), ".html")
+		t.Run(name, func(t *testing.T) {
+			raw, err := os.ReadFile(strings.TrimSuffix(page, ".html") + ".json")
+			require.NoError(t, err)
+
+			var expected articleFixture
+			require.NoError(t, json.Unmarshal(raw, &expected))
+
+			f, err := os.Open(page)
+			require.NoError(t, err)
+			defer func() { _ = f.Close() }()
+
+			doc, err := NewElement(f)
+			require.NoError(t, err)
+
+			article, err := doc.Article()
+			require.NoError(t, err)
+
+			assert.Equal(t, expected.Title, article.Title)
+			assert.Equal(t, expected.Byline, article.Byline)
+			if expected.Published == "" {
+				assert.True(t, article.Published.IsZero(), "published %s", article.Published)
+			} else {
+				published, err := time.Parse(time.RFC3339, expected.Published)
+				require.NoError(t, err)
+				assert.True(t, published.Equal(article.Published), "published %s", article.Published)
+			}
+
+			for _, text := range expected.Contains {
+				assert.Contains(t, article.Text, text)
+			}
+			for _, text := range expected.Excludes {
+				assert.NotContains(t, article.Text, text)
+			}
+			assert.Contains(t, article.Text, "\n\n", "blocks are separated")
+		})
+	}
+
+	t.Run("document unchanged", func(t *testing.T) {
+		doc, err := NewElement(strings.NewReader(`<html><body><nav>Menu</nav><div><p>` + strings.Repeat("Some long paragraph text, ", 10) + `</p></div></body></html>`))
+		require.NoError(t, err)
+
+		_, err = doc.Article()
+		require.NoError(t, err)
+		assert.Equal(t, "Menu", doc.Collect("nav"))
+	})
+
+	t.Run("forms", func(t *testing.T) {
+		paragraphs := strings.Repeat(`<p>`+strings.Repeat("Some long paragraph text, ", 10)+`</p>`, 3)
+
+		doc, err := NewElement(strings.NewReader(`<html><body><div class="post">` + paragraphs + `<form action="/subscribe"><p>Sign up for the newsletter</p><input type="email" name="email"></form></div></body></html>`))
+		require.NoError(t, err)
+		article, err := doc.Article()
+		require.NoError(t, err)
+		assert.NotContains(t, article.Text, "newsletter")
+
+		doc, err = NewElement(strings.NewReader(`<html><body><form method="post"><input type="text" name="q"><select name="lang"><option>English</option></select><div class="post">` + paragraphs + `</div><textarea name="comment"></textarea></form></body></html>`))
+		require.NoError(t, err)
+		article, err = doc.Article()
+		require.NoError(t, err)
+		assert.Contains(t, article.Text, "Some long paragraph text", "the form contains the content")
+	})
+
+	t.Run("metadata", func(t *testing.T) {
+		doc, err := NewElement(strings.NewReader(`<html><head><title>Transit plan approved | Daily Ledger</title>
+			<script type="application/ld+json">{"@type": "NewsArticle", "datePublished": "2026-03-14T08:30:00Z", "author": [{"@type": "Person", "name": "Maria Lopez"}]}</script>
+			</head><body><article><h1>Transit plan approved</h1><p class="byline">By Maria Lopez</p><div><p>` + strings.Repeat("Some long paragraph text, ", 10) + `</p></div></article></body></html>`))
+		require.NoError(t, err)
+
+		article, err := doc.Article()
+		require.NoError(t, err)
+		assert.Equal(t, "Transit plan approved", article.Title)
+		assert.Equal(t, "Maria Lopez", article.Byline)
+		assert.Equal(t, time.Date(2026, 3, 14, 8, 30, 0, 0, time.UTC), article.Published)
+		assert.NotContains(t, article.Text, "By Maria Lopez")
+	})
+
+	t.Run("published", func(t *testing.T) {
+		body := `<div><p>` + strings.Repeat("Some long paragraph text, ", 10) + `</p></div>`
+
+		doc, err := NewElement(strings.NewReader(`<html><body><time>March 3rd, 2026</time>` + body + `</body></html>`))
+		require.NoError(t, err)
+		article, err := doc.Article()
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC), article.Published)
+
+		doc, err = NewElement(strings.NewReader(`<html><body><time>03/04/2026</time>` + body + `</body></html>`))
+		require.NoError(t, err)
+		article, err = doc.Article()
+		require.NoError(t, err)
+		assert.True(t, article.Published.IsZero(), "ambiguous day/month")
+	})
+
+	t.Run("no content", func(t *testing.T) {
+		doc, err := NewElement(strings.NewReader(`<html><body><nav><a href="/">Home</a></nav></body></html>`))
+		require.NoError(t, err)
+
+		_, err = doc.Article()
+		assert.ErrorIs(t, err, ErrNoContent)
+	})
+}
@@ -0,0 +1,458 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package parser
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+type renderOptions struct {
+	stripImages   bool
+	stripLinkURLs bool
+}
+
+type RenderOption func(*renderOptions)
+
+// Omits the images, instead of rendering them (Markdown) or their alt text (plain text).
+func WithoutImages() RenderOption {
+	return func(o *renderOptions) {
+		o.stripImages = true
+	}
+}
+
+// Renders the links as their text only (Markdown).
+func WithoutLinkURLs() RenderOption {
+	return func(o *renderOptions) {
+		o.stripLinkURLs = true
+	}
+}
+
+// Renders the element as Markdown (CommonMark, with GFM tables and
+// strikethrough), preserving the headings, paragraphs, lists, quotes, links,
+// images, emphasis, code and tables. The scripts, styles and `head` are skipped.
+func (e *Element) Markdown(opts ...RenderOption) string {
+	return e.render(true, opts)
+}
+
+// Renders the element as plain text, unlike [Element.Text] which flattens it,
+// the blocks (eg: paragraphs, headings) are separated by blank lines, the list
+// items and table rows by line breaks, and the table cells by tabs.
+func (e *Element) PlainText(opts ...RenderOption) string {
+	return e.render(false, opts)
+}
+
+func (e *Element) render(markdown bool, opts []RenderOption) string {
+	r := renderer{markdown: markdown}
+	for _, opt := range opts {
+		opt(&r.options)
+	}
+	return strings.Join(r.blocks(e.dom.Nodes), "\n\n")
+}
+
+// Elements rendered on their own, rather than within the surrounding text.
+var blockElements = map[string]struct{}{
+	"address": {}, "article": {}, "aside": {}, "blockquote": {}, "body": {}, "dd": {}, "details": {},
+	"dialog": {}, "div": {}, "dl": {}, "dt": {}, "fieldset": {}, "figcaption": {}, "figure": {},
+	"footer": {}, "form": {}, "h1": {}, "h2": {}, "h3": {}, "h4": {}, "h5": {}, "h6": {},
+	"header": {}, "hgroup": {}, "hr": {}, "html": {}, "li": {}, "main": {}, "nav": {}, "ol": {},
+	"p": {}, "pre": {}, "section": {}, "summary": {}, "table": {}, "ul": {},
+}
+
+// Elements without any readable content.
+var skippedElements = map[string]struct{}{
+	"head": {}, "script": {}, "style": {}, "noscript": {}, "template": {}, "svg": {}, "canvas": {},
+	"button": {}, "input": {}, "select": {}, "textarea": {},
+}
+
+var (
+	whitespace     = regexp.MustCompile(`\s+`)
+	repeatedSpaces = regexp.MustCompile(` {2,}`)
+
+	// Markers of the blocks at the start of the line (eg: "# ", "> ", "- ", "1. ")
+	blockMarker = regexp.MustCompile(`^(>|#{1,6}(\s|$)|[-+=](\s|$)|-{2,}|={2,}|\d{1,9}[.)](\s|$))`)
+
+	// Characters of the text with a meaning within the Markdown line
+	markdownEscaper = strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`)
+)
+
+type renderer struct {
+	options  renderOptions
+	markdown bool
+}
+
+// Renders the nodes into blocks, the consecutive inline nodes form a paragraph.
+func (r *renderer) blocks(nodes []*html.Node) []string {
+	var blocks []string
+	var inline strings.Builder
+
+	flush := func() {
+		if paragraph := r.paragraph(inline.String()); paragraph != "" {
+			blocks = append(blocks, paragraph)
+		}
+		inline.Reset()
+	}
+
+	for _, node := range nodes {
+		if isBlock(node) {
+			flush()
+			if block := r.block(node); block != "" {
+				blocks = append(blocks, block)
+			}
+			continue
+		}
+		inline.WriteString(r.inline(node))
+	}
+	flush()
+
+	return blocks
+}
+
+// Collapses the whitespace of the inline content, while retaining the line breaks.
+func (r *renderer) paragraph(text string) string {
+	lines := strings.Split(text, "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		if line = strings.TrimSpace(repeatedSpaces.ReplaceAllString(line, " ")); line != "" {
+			kept = append(kept, line)
+		}
+	}
+
+	if r.markdown {
+		for i, line := range kept {
+			kept[i] = escapeBlockMarker(line)
+		}
+		return strings.Join(kept, "  \n")
+	}
+	return strings.Join(kept, "\n")
+}
+
+func (r *renderer) block(n *html.Node) string {
+	children := childNodes(n)
+
+	switch n.Data {
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		text := r.paragraph(r.inlineChildren(n))
+		if text == "" || !r.markdown {
+			return text
+		}
+		level, _ := strconv.Atoi(n.Data[1:])
+		return strings.Repeat("#", level) + " " + strings.ReplaceAll(text, "  \n", " ")
+	case "blockquote":
+		text := strings.Join(r.blocks(children), "\n\n")
+		if !r.markdown || text == "" {
+			return text
+		}
+		return prefixLines(text, "> ", ">")
+	case "ul", "ol":
+		return r.list(n)
+	case "li":
+		// Outside of a list
+		return r.listItem(n, "- ")
+	case "pre":
+		return r.pre(n)
+	case "hr":
+		if r.markdown {
+			return "---"
+		}
+		return ""
+	case "table":
+		return r.table(n)
+	default:
+		return strings.Join(r.blocks(children), "\n\n")
+	}
+}
+
+func (r *renderer) inline(n *html.Node) string {
+	switch n.Type {
+	case html.TextNode:
+		text := whitespace.ReplaceAllString(n.Data, " ")
+		if r.markdown {
+			return escapeMarkdown(text)
+		}
+		return text
+	case html.ElementNode:
+	default:
+		return ""
+	}
+	if _, ok := skippedElements[n.Data]; ok {
+		return ""
+	}
+
+	switch n.Data {
+	case "br":
+		return "\n"
+	case "img":
+		if r.options.stripImages {
+			return ""
+		}
+		alt := strings.TrimSpace(attr(n, "alt"))
+		if !r.markdown {
+			return alt
+		}
+		src := strings.TrimSpace(attr(n, "src"))
+		if src == "" {
+			return alt
+		}
+		return "![" + escapeMarkdown(alt) + "](" + markdownURL(src) + ")"
+	case "a":
+		text := r.inlineChildren(n)
+		href := strings.TrimSpace(attr(n, "href"))
+		if !r.markdown || r.options.stripLinkURLs || href == "" || strings.HasPrefix(href, "javascript:") {
+			return text
+		}
+		if strings.TrimSpace(text) == "" {
+			return text
+		}
+		return wrapInline(text, "[", "]("+markdownURL(href)+")")
+	case "code", "kbd", "samp", "tt":
+		text := whitespace.ReplaceAllString(textContent(n), " ")
+		if !r.markdown || strings.TrimSpace(text) == "" {
+			return text
+		}
+		// The fence is longer than the backticks within, which are padded
+		// with a space at the ends so they aren't taken as the fence.
+		fence := "`"
+		for strings.Contains(text, fence) {
+			fence += "`"
+		}
+		if trimmed := strings.TrimSpace(text); strings.HasPrefix(trimmed, "`") || strings.HasSuffix(trimmed, "`") {
+			return wrapInline(text, fence+" ", " "+fence)
+		}
+		return wrapInline(text, fence, fence)
+	}
+
+	text := r.inlineChildren(n)
+	if !r.markdown {
+		return text
+	}
+	switch n.Data {
+	case "strong", "b":
+		return wrapInline(text, "**", "**")
+	case "em", "i":
+		return wrapInline(text, "_", "_")
+	case "del", "s", "strike":
+		return wrapInline(text, "~~", "~~")
+	}
+	return text
+}
+
+func (r *renderer) inlineChildren(n *html.Node) string {
+	var b strings.Builder
+	for _, child := range childNodes(n) {
+		if isBlock(child) {
+			// Blocks within inline elements (eg: div within a)
+			b.WriteString(" " + r.inlineChildren(child) + " ")
+			continue
+		}
+		b.WriteString(r.inline(child))
+	}
+	return b.String()
+}
+
+func (r *renderer) list(n *html.Node) string {
+	number := 1
+	if start, err := strconv.Atoi(attr(n, "start")); err == nil {
+		number = start
+	}
+
+	var items []string
+	for _, child := range childNodes(n) {
+		if child.Type != html.ElementNode || child.Data != "li" {
+			continue
+		}
+		marker := "- "
+		if n.Data == "ol" {
+			marker = strconv.Itoa(number) + ". "
+			number++
+		}
+		if item := r.listItem(child, marker); item != "" {
+			items = append(items, item)
+		}
+	}
+	return strings.Join(items, "\n")
+}
+
+// Renders the item with the marker, the continuation lines (eg: nested lists)
+// are indented to the content of the item.
+func (r *renderer) listItem(n *html.Node, marker string) string {
+	text := strings.Join(r.blocks(childNodes(n)), "\n")
+	if text == "" {
+		return ""
+	}
+	return marker + prefixLines(text, strings.Repeat(" ", len(marker)), "")[len(marker):]
+}
+
+func (r *renderer) pre(n *html.Node) string {
+	text := strings.TrimRight(strings.TrimPrefix(textContent(n), "\n"), " \t\n")
+	if !r.markdown {
+		return text
+	}
+
+	language := ""
+	for _, node := range append([]*html.Node{n}, childNodes(n)...) {
+		for class := range strings.FieldsSeq(attr(node, "class")) {
+			if lang, ok := strings.CutPrefix(class, "language-"); ok {
+				language = lang
+			}
+		}
+	}
+
+	fence := "```"
+	for strings.Contains(text, fence) {
+		fence += "`"
+	}
+	return fence + language + "\n" + text + "\n" + fence
+}
+
+func (r *renderer) table(n *html.Node) string {
+	var rows [][]string
+	var walk func(*html.Node)
+	walk = func(node *html.Node) {
+		for _, child := range childNodes(node) {
+			if child.Type != html.ElementNode {
+				continue
+			}
+			switch child.Data {
+			case "tr":
+				var cells []string
+				for _, cell := range childNodes(child) {
+					if cell.Type == html.ElementNode && (cell.Data == "td" || cell.Data == "th") {
+						cells = append(cells, strings.Join(strings.Fields(r.inlineChildren(cell)), " "))
+					}
+				}
+				rows = append(rows, cells)
+			case "thead", "tbody", "tfoot":
+				walk(child)
+			}
+		}
+	}
+	walk(n)
+	if len(rows) == 0 {
+		return ""
+	}
+
+	if !r.markdown {
+		lines := make([]string, 0, len(rows))
+		for _, row := range rows {
+			lines = append(lines, strings.Join(row, "\t"))
+		}
+		return strings.Join(lines, "\n")
+	}
+
+	columns := 0
+	for _, row := range rows {
+		columns = max(columns, len(row))
+	}
+
+	line := func(cells []string) string {
+		padded := make([]string, columns)
+		for i := range padded {
+			if i < len(cells) {
+				padded[i] = strings.ReplaceAll(cells[i], "|", `\|`)
+			}
+		}
+		return "| " + strings.Join(padded, " | ") + " |"
+	}
+
+	lines := []string{line(rows[0]), "|" + strings.Repeat(" --- |", columns)}
+	for _, row := range rows[1:] {
+		lines = append(lines, line(row))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func isBlock(n *html.Node) bool {
+	if n.Type == html.DocumentNode {
+		return true
+	}
+	if n.Type != html.ElementNode {
+		return false
+	}
+	if _, ok := skippedElements[n.Data]; ok {
+		return true
+	}
+	_, ok := blockElements[n.Data]
+	return ok
+}
+
+func childNodes(n *html.Node) []*html.Node {
+	if n.Type == html.ElementNode {
+		if _, ok := skippedElements[n.Data]; ok {
+			return nil
+		}
+	}
+	var children []*html.Node
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		children = append(children, child)
+	}
+	return children
+}
+
+func textContent(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	var b strings.Builder
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		b.WriteString(textContent(child))
+	}
+	return b.String()
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+// Wraps the inline text, keeping the surrounding spaces outside of the markers.
+func wrapInline(text, open, close string) string {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return text
+	}
+	leading := text[:len(text)-len(strings.TrimLeft(text, " \n"))]
+	trailing := text[len(strings.TrimRight(text, " \n")):]
+	return leading + open + trimmed + close + trailing
+}
+
+func prefixLines(text, prefix, emptyPrefix string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = emptyPrefix
+		} else {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func escapeMarkdown(text string) string {
+	return markdownEscaper.Replace(text)
+}
+
+// Escapes the marker at the start of the line, so the text isn't taken as
+// a heading, quote, list item or thematic break.
+func escapeBlockMarker(line string) string {
+	marker := blockMarker.FindString(line)
+	if marker == "" {
+		return line
+	}
+	if i := strings.IndexAny(marker, ".)"); i > 0 {
+		return line[:i] + `\` + line[i:]
+	}
+	return `\` + line
+}
+
+func markdownURL(url string) string {
+	return strings.NewReplacer(" ", "%20", "(", "%28", ")", "%29").Replace(url)
+}
@@ -0,0 +1,91 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender(t *testing.T) {
+	page := `<html><head><title>Ignored</title><style>p { color: red }</style></head><body>
+		<h1>Release   notes</h1>
+		<p>Version <strong>2.0</strong> is <em>out</em>, see the <a href="/docs/upgrade guide">upgrade guide</a>.<br>
+		Thanks to all <del>three</del> contributors!</p>
+		<script>track()</script>
+		<ul>
+			<li>Faster <code>Find</code></li>
+			<li>New renderers
+				<ol start="3"><li>Markdown</li><li>Plain text</li></ol>
+			</li>
+		</ul>
+		<blockquote><p>Ship it.</p><p>Again.</p></blockquote>
+		<pre><code class="language-go">func main() {
+	fmt.Println("hi")
+}
+</code></pre>
+		<p><img src="/chart.png" alt="Chart"> Loose text</p>
+		<hr>
+		<table>
+			<thead><tr><th>Name</th><th>Value</th></tr></thead>
+			<tbody><tr><td>a|b</td><td>1</td></tr><tr><td>c</td></tr></tbody>
+		</table>
+	</body></html>`
+
+	doc, err := NewElement(strings.NewReader(page))
+	require.NoError(t, err)
+
+	t.Run("markdown", func(t *testing.T) {
+		expected := strings.Join([]string{
+			"# Release notes",
+			"Version **2.0** is _out_, see the [upgrade guide](/docs/upgrade%20guide).  \nThanks to all ~~three~~ contributors!",
+			"- Faster `Find`\n- New renderers\n  3. Markdown\n  4. Plain text",
+			"> Ship it.\n>\n> Again.",
+			"```go\nfunc main() {\n\tfmt.Println(\"hi\")\n}\n```",
+			"![Chart](/chart.png) Loose text",
+			"---",
+			"| Name | Value |\n| --- | --- |\n| a\\|b | 1 |\n| c |  |",
+		}, "\n\n")
+		assert.Equal(t, expected, doc.Markdown())
+	})
+
+	t.Run("markdown options", func(t *testing.T) {
+		md := doc.Find("body > p").Markdown(WithoutImages(), WithoutLinkURLs())
+		assert.Equal(t, "Version **2.0** is _out_, see the upgrade guide.  \nThanks to all ~~three~~ contributors!\n\nLoose text", md)
+	})
+
+	t.Run("markdown escaping", func(t *testing.T) {
+		doc, err := NewElement(strings.NewReader(`<body>
+			<p>2 * 3 = 6, snake_case and [brackets] in C:\dir</p>
+			<p>1. Not a list<br>- nor this<br># nor a heading<br>&gt; nor a quote<br>---<br>-5 degrees</p>
+			<p><code>a ` + "``b``" + ` c</code> and <code>` + "`" + `tick</code></p>
+		</body>`))
+		require.NoError(t, err)
+
+		expected := strings.Join([]string{
+			`2 \* 3 = 6, snake\_case and \[brackets\] in C:\\dir`,
+			"1\\. Not a list  \n\\- nor this  \n\\# nor a heading  \n\\> nor a quote  \n\\---  \n-5 degrees",
+			"```a ``b`` c``` and `` `tick ``",
+		}, "\n\n")
+		assert.Equal(t, expected, doc.Markdown())
+		assert.Contains(t, doc.PlainText(), "2 * 3 = 6, snake_case and [brackets] in C:\\dir\n\n1. Not a list")
+	})
+
+	t.Run("plain text", func(t *testing.T) {
+		expected := strings.Join([]string{
+			"Release notes",
+			"Version 2.0 is out, see the upgrade guide.\nThanks to all three contributors!",
+			"- Faster Find\n- New renderers\n  3. Markdown\n  4. Plain text",
+			"Ship it.\n\nAgain.",
+			"func main() {\n\tfmt.Println(\"hi\")\n}",
+			"Chart Loose text",
+			"Name\tValue\na|b\t1\nc",
+		}, "\n\n")
+		assert.Equal(t, expected, doc.PlainText())
+		assert.Equal(t, "Loose text", doc.Find("p:has(img)").PlainText(WithoutImages()))
+	})
+}
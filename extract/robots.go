@@ -0,0 +1,24 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package parser
+
+import (
+	"github.com/PuerkitoBio/goquery"
+	"github.com/ritvikos/synapse/robots/directives"
+)
+
+// Page-level directives of the document, declared via `<meta name="robots">`
+// and `<meta name="<user-agent>">` (eg: "googlebot") for the user-agent token.
+//
+// Merge with the `X-Robots-Tag` header of the response (see [directives.ParseXRobotsTag]),
+// for the complete set of directives.
+func (e *Element) RobotsDirectives(userAgent string) directives.Directives {
+	var robots directives.Directives
+	e.root().Find("meta[name][content]").Each(func(_ int, s *goquery.Selection) {
+		if d, ok := directives.ParseMeta(s.AttrOr("name", ""), s.AttrOr("content", ""), userAgent); ok {
+			robots = robots.Merge(d)
+		}
+	})
+	return robots
+}
@@ -0,0 +1,297 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package parser
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/ritvikos/synapse/extract/transform"
+)
+
+// Syntax the structured data item was embedded with.
+type Syntax string
+
+const (
+	SyntaxJSONLD    Syntax = "json-ld"
+	SyntaxMicrodata Syntax = "microdata"
+	SyntaxRDFa      Syntax = "rdfa"
+)
+
+// Item is an entity of the structured data (eg: schema.org Product), normalized
+// across JSON-LD, Microdata and RDFa Lite.
+//
+// The schema.org types and properties are shortened to their names
+// (eg: "https://schema.org/Product" to "Product"), other vocabularies
+// retain the full IRI.
+type Item struct {
+	// Property values in the document order, keyed by the property name
+	Properties map[string][]Value
+
+	// Identifier of the item (`@id`, `itemid` or `resource`), if any
+	ID string
+
+	Syntax Syntax
+
+	// Types of the item (eg: ["Product"]), can be empty
+	Types []string
+}
+
+// Value is either a literal (or IRI) or a nested item.
+type Value struct {
+	// Nested item, nil for the literals
+	Item *Item
+
+	// Literal or IRI, empty for the nested items
+	Text string
+}
+
+func newItem(syntax Syntax) *Item {
+	return &Item{Properties: make(map[string][]Value), Syntax: syntax}
+}
+
+func (i *Item) add(property string, value Value) {
+	property = normalizeTerm(property)
+	i.Properties[property] = append(i.Properties[property], value)
+}
+
+// Whether the item is of the type (eg: "Product", "https://schema.org/Product").
+func (i *Item) Is(typ string) bool {
+	typ = normalizeTerm(typ)
+	for _, t := range i.Types {
+		if t == typ {
+			return true
+		}
+	}
+	return false
+}
+
+func (i *Item) Values(property string) []Value {
+	return i.Properties[normalizeTerm(property)]
+}
+
+// First literal value of the property (eg: "name"), or empty if none.
+// A nested item yields its "name", or its identifier.
+func (i *Item) Get(property string) string {
+	for _, v := range i.Values(property) {
+		if v.Item == nil {
+			return v.Text
+		}
+		if name := v.Item.Get("name"); name != "" {
+			return name
+		}
+		if v.Item.ID != "" {
+			return v.Item.ID
+		}
+	}
+	return ""
+}
+
+// All literal values of the property.
+func (i *Item) GetAll(property string) []string {
+	var values []string
+	for _, v := range i.Values(property) {
+		if v.Item == nil {
+			values = append(values, v.Text)
+		}
+	}
+	return values
+}
+
+// First nested item of the property (eg: "offers"), or nil if none.
+func (i *Item) GetItem(property string) *Item {
+	for _, v := range i.Values(property) {
+		if v.Item != nil {
+			return v.Item
+		}
+	}
+	return nil
+}
+
+// OpenGraph is the `og:` metadata of the page (https://ogp.me).
+type OpenGraph struct {
+	// All the properties, including the typed ones and the ones of the object
+	// types (eg: "og:image:width", "article:published_time")
+	Properties map[string][]string
+
+	Title       string
+	Type        string
+	URL         string
+	Description string
+	SiteName    string
+	Locale      string
+	Images      []string
+}
+
+// TwitterCard is the `twitter:` metadata of the page.
+type TwitterCard struct {
+	// All the properties, including the typed ones
+	Properties map[string]string
+
+	Card        string
+	Site        string
+	Creator     string
+	Title       string
+	Description string
+	Image       string
+}
+
+// StructuredData is all the metadata embedded within the page.
+type StructuredData struct {
+	OpenGraph   OpenGraph
+	TwitterCard TwitterCard
+
+	// Top-level items of JSON-LD, Microdata and RDFa Lite, in that order
+	Items []*Item
+}
+
+// Items of the type, including the nested ones (eg: "Offer" within "Product").
+func (d *StructuredData) Find(typ string) []*Item {
+	var found []*Item
+	seen := make(map[*Item]struct{})
+
+	var walk func(items []*Item)
+	walk = func(items []*Item) {
+		for _, item := range items {
+			if _, ok := seen[item]; ok {
+				continue
+			}
+			seen[item] = struct{}{}
+
+			if item.Is(typ) {
+				found = append(found, item)
+			}
+			for _, values := range item.Properties {
+				for _, v := range values {
+					if v.Item != nil {
+						walk([]*Item{v.Item})
+					}
+				}
+			}
+		}
+	}
+	walk(d.Items)
+
+	return found
+}
+
+// Extracts the structured data within the element, while the OpenGraph and
+// Twitter card metadata is read from the whole document.
+func (e *Element) StructuredData() *StructuredData {
+	var items []*Item
+	items = append(items, e.JSONLD()...)
+	items = append(items, e.Microdata()...)
+	items = append(items, e.RDFa()...)
+
+	return &StructuredData{
+		OpenGraph:   e.OpenGraph(),
+		TwitterCard: e.TwitterCard(),
+		Items:       items,
+	}
+}
+
+// Prefixes of the OpenGraph properties, including the object types.
+var openGraphPrefixes = []string{"og:", "article:", "book:", "profile:", "music:", "video:", "product:"}
+
+func (e *Element) OpenGraph() OpenGraph {
+	og := OpenGraph{Properties: make(map[string][]string)}
+
+	e.root().Find("meta[property][content], meta[name][content]").Each(func(_ int, s *goquery.Selection) {
+		property := strings.ToLower(strings.TrimSpace(s.AttrOr("property", s.AttrOr("name", ""))))
+		if !hasAnyPrefix(property, openGraphPrefixes) {
+			return
+		}
+
+		content := strings.TrimSpace(s.AttrOr("content", ""))
+		og.Properties[property] = append(og.Properties[property], content)
+
+		switch property {
+		case "og:title":
+			og.Title = firstNonEmpty(og.Title, content)
+		case "og:type":
+			og.Type = firstNonEmpty(og.Type, content)
+		case "og:url":
+			og.URL = firstNonEmpty(og.URL, content)
+		case "og:description":
+			og.Description = firstNonEmpty(og.Description, content)
+		case "og:site_name":
+			og.SiteName = firstNonEmpty(og.SiteName, content)
+		case "og:locale":
+			og.Locale = firstNonEmpty(og.Locale, content)
+		case "og:image", "og:image:url":
+			if content != "" {
+				og.Images = append(og.Images, content)
+			}
+		}
+	})
+
+	return og
+}
+
+func (e *Element) TwitterCard() TwitterCard {
+	card := TwitterCard{Properties: make(map[string]string)}
+
+	e.root().Find("meta[name][content], meta[property][content]").Each(func(_ int, s *goquery.Selection) {
+		name := strings.ToLower(strings.TrimSpace(s.AttrOr("name", s.AttrOr("property", ""))))
+		if !strings.HasPrefix(name, "twitter:") {
+			return
+		}
+		if _, ok := card.Properties[name]; ok {
+			return
+		}
+
+		content := strings.TrimSpace(s.AttrOr("content", ""))
+		card.Properties[name] = content
+
+		switch name {
+		case "twitter:card":
+			card.Card = content
+		case "twitter:site":
+			card.Site = content
+		case "twitter:creator":
+			card.Creator = content
+		case "twitter:title":
+			card.Title = content
+		case "twitter:description":
+			card.Description = content
+		case "twitter:image", "twitter:image:src":
+			card.Image = firstNonEmpty(card.Image, content)
+		}
+	})
+
+	return card
+}
+
+// Shortens the schema.org IRIs (eg: "https://schema.org/name" to "name").
+func normalizeTerm(term string) string {
+	term = strings.TrimSpace(term)
+	for _, prefix := range []string{"https://schema.org/", "http://schema.org/", "schema:"} {
+		if strings.HasPrefix(term, prefix) {
+			return term[len(prefix):]
+		}
+	}
+	return term
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func elementText(s *goquery.Selection) string {
+	return transform.NormalizeWhitespace(s.Text())
+}
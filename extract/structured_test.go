@@ -0,0 +1,140 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStructuredData(t *testing.T) {
+	page := `<html prefix="og: https://ogp.me/ns#"><head>
+		<meta property="og:title" content="Trail Shoe">
+		<meta property="og:type" content="product">
+		<meta property="og:image" content="https://example.com/a.jpg">
+		<meta property="og:image" content="https://example.com/b.jpg">
+		<meta property="og:image:width" content="800">
+		<meta property="article:published_time" content="2026-01-02">
+		<meta name="twitter:card" content="summary_large_image">
+		<meta name="twitter:site" content="@example">
+		<meta name="twitter:image:src" content="https://example.com/t.jpg">
+		<script type="application/ld+json">
+		<!--
+		{
+			"@context": "https://schema.org",
+			"@graph": [
+				{"@type": "Organization", "@id": "#org", "name": "Example Inc."},
+				{
+					"@type": ["Product", "https://schema.org/IndividualProduct"],
+					"name": "Trail Shoe",
+					"sku": 1042,
+					"brand": {"@id": "#org"},
+					"description": {"@value": "Light and grippy", "@language": "en"},
+					"offers": [{"@type": "Offer", "price": "89.90", "priceCurrency": "EUR", "availability": "https://schema.org/InStock"}]
+				}
+			]
+		}
+		-->
+		</script>
+		<script type="application/ld+json">{ broken </script>
+	</head><body>
+		<div itemscope itemtype="https://schema.org/Recipe" itemref="author">
+			<h1 itemprop="name">Pancakes</h1>
+			<img itemprop="image" src="/pancakes.jpg">
+			<time itemprop="cookTime" datetime="PT20M">20 minutes</time>
+			<ul><li itemprop="recipeIngredient">Flour</li><li itemprop="recipeIngredient">Milk</li></ul>
+			<div itemprop="nutrition" itemscope itemtype="https://schema.org/NutritionInformation">
+				<meta itemprop="calories" content="240">
+			</div>
+		</div>
+		<p id="author" itemprop="author">Jane Doe</p>
+
+		<div vocab="https://schema.org/" typeof="Event">
+			<span property="name">Meetup</span>
+			<a property="url" href="https://example.com/meetup">Details</a>
+			<div property="location" typeof="Place">
+				<span property="name">Town Hall</span>
+			</div>
+			<span property="og:description">Monthly meetup</span>
+		</div>
+	</body></html>`
+
+	doc, err := NewElement(strings.NewReader(page))
+	require.NoError(t, err)
+
+	data := doc.StructuredData()
+
+	t.Run("json-ld", func(t *testing.T) {
+		items := doc.JSONLD()
+		require.Len(t, items, 2, "graph is flattened, malformed block skipped")
+
+		product := items[1]
+		assert.Equal(t, SyntaxJSONLD, product.Syntax)
+		assert.Equal(t, []string{"Product", "IndividualProduct"}, product.Types)
+		assert.True(t, product.Is("https://schema.org/Product"))
+		assert.Equal(t, "Trail Shoe", product.Get("name"))
+		assert.Equal(t, "1042", product.Get("sku"))
+		assert.Equal(t, "Light and grippy", product.Get("description"))
+		assert.Equal(t, "#org", product.Get("brand"))
+
+		offer := product.GetItem("offers")
+		require.NotNil(t, offer)
+		assert.Equal(t, "89.90", offer.Get("price"))
+		assert.Equal(t, "https://schema.org/InStock", offer.Get("availability"))
+	})
+
+	t.Run("microdata", func(t *testing.T) {
+		items := doc.Microdata()
+		require.Len(t, items, 1)
+
+		recipe := items[0]
+		assert.Equal(t, []string{"Recipe"}, recipe.Types)
+		assert.Equal(t, "Pancakes", recipe.Get("name"))
+		assert.Equal(t, "/pancakes.jpg", recipe.Get("image"))
+		assert.Equal(t, "PT20M", recipe.Get("cookTime"))
+		assert.Equal(t, []string{"Flour", "Milk"}, recipe.GetAll("recipeIngredient"))
+		assert.Equal(t, "Jane Doe", recipe.Get("author"), "via itemref")
+		assert.Equal(t, "240", recipe.GetItem("nutrition").Get("calories"))
+		assert.Empty(t, recipe.Values("calories"), "belongs to the nested item")
+	})
+
+	t.Run("rdfa", func(t *testing.T) {
+		items := doc.RDFa()
+		require.Len(t, items, 1)
+
+		event := items[0]
+		assert.Equal(t, []string{"Event"}, event.Types)
+		assert.Equal(t, "Meetup", event.Get("name"))
+		assert.Equal(t, "https://example.com/meetup", event.Get("url"))
+		assert.Equal(t, "Town Hall", event.Get("location"))
+		assert.True(t, event.GetItem("location").Is("Place"))
+		assert.Equal(t, "Monthly meetup", event.Get("https://ogp.me/ns#description"), "prefixed terms are expanded")
+	})
+
+	t.Run("find nested", func(t *testing.T) {
+		require.Len(t, data.Items, 4)
+		assert.Len(t, data.Find("Offer"), 1)
+		assert.Len(t, data.Find("Place"), 1)
+		assert.Empty(t, data.Find("Book"))
+	})
+
+	t.Run("opengraph", func(t *testing.T) {
+		og := data.OpenGraph
+		assert.Equal(t, "Trail Shoe", og.Title)
+		assert.Equal(t, "product", og.Type)
+		assert.Equal(t, []string{"https://example.com/a.jpg", "https://example.com/b.jpg"}, og.Images)
+		assert.Equal(t, []string{"800"}, og.Properties["og:image:width"])
+		assert.Equal(t, []string{"2026-01-02"}, og.Properties["article:published_time"])
+	})
+
+	t.Run("twitter card", func(t *testing.T) {
+		card := data.TwitterCard
+		assert.Equal(t, "summary_large_image", card.Card)
+		assert.Equal(t, "@example", card.Site)
+		assert.Equal(t, "https://example.com/t.jpg", card.Image)
+	})
+}
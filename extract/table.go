@@ -0,0 +1,273 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package parser
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/ritvikos/synapse/extract/transform"
+	"golang.org/x/net/html"
+)
+
+// Upper bound of the colspan and rowspan, against the malformed tables.
+const maxTableSpan = 1000
+
+// Table is a normalized grid of the HTML table, where the cells spanning
+// multiple columns or rows (colspan, rowspan) are repeated in each of them.
+type Table struct {
+	Caption string
+
+	// Column names, nil if the table has no header
+	Header []string
+
+	// Body rows (excluding the header), each with one cell per column
+	Rows [][]string
+}
+
+type tableOptions struct {
+	// Number of header rows, -1 to detect
+	headerRows int
+}
+
+type TableOption func(*tableOptions)
+
+// Treats the first n rows as the header, instead of detecting it
+// (i.e. the `thead` rows, or the first row if it only has `th` cells).
+// The multiple header rows are joined per column (eg: "Price USD").
+// Zero treats all the rows as the body.
+func WithHeaderRows(n int) TableOption {
+	return func(o *tableOptions) {
+		o.headerRows = max(n, 0)
+	}
+}
+
+// Extracts the tables within the element (including itself), in the document
+// order. The nested tables are extracted separately, their rows don't
+// belong to the outer table.
+func (e *Element) Tables(opts ...TableOption) []*Table {
+	options := tableOptions{headerRows: -1}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	var tables []*Table
+	scopes(e.dom, "table").Each(func(_ int, s *goquery.Selection) {
+		tables = append(tables, parseTable(s.Nodes[0], options))
+	})
+	return tables
+}
+
+// Number of columns.
+func (t *Table) Width() int {
+	if len(t.Header) > 0 {
+		return len(t.Header)
+	}
+	if len(t.Rows) > 0 {
+		return len(t.Rows[0])
+	}
+	return 0
+}
+
+// Rows keyed by the header, or by the column number ("1", "2", ...) if the
+// table has no header. The repeated names are suffixed (eg: "Price", "Price 2").
+func (t *Table) Maps() []map[string]string {
+	keys := make([]string, t.Width())
+	seen := make(map[string]int)
+	for i := range keys {
+		key := strconv.Itoa(i + 1)
+		if i < len(t.Header) && t.Header[i] != "" {
+			key = t.Header[i]
+		}
+		if seen[key]++; seen[key] > 1 {
+			key += " " + strconv.Itoa(seen[key])
+		}
+		keys[i] = key
+	}
+
+	maps := make([]map[string]string, 0, len(t.Rows))
+	for _, row := range t.Rows {
+		m := make(map[string]string, len(keys))
+		for i, key := range keys {
+			m[key] = row[i]
+		}
+		maps = append(maps, m)
+	}
+	return maps
+}
+
+// Writes the table as CSV (RFC 4180), the header first (if any).
+func (t *Table) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if t.Header != nil {
+		if err := writer.Write(t.Header); err != nil {
+			return err
+		}
+	}
+	if err := writer.WriteAll(t.Rows); err != nil {
+		return err
+	}
+	return writer.Error()
+}
+
+type tableRow struct {
+	cells  []*html.Node
+	header bool
+}
+
+func parseTable(node *html.Node, options tableOptions) *Table {
+	table := &Table{}
+
+	var rows []tableRow
+	var footer []tableRow
+	for _, child := range childNodes(node) {
+		if child.Type != html.ElementNode {
+			continue
+		}
+		switch child.Data {
+		case "caption":
+			table.Caption = transform.NormalizeWhitespace(textContent(child))
+		case "thead":
+			rows = append(rows, sectionRows(child, true)...)
+		case "tbody":
+			rows = append(rows, sectionRows(child, false)...)
+		case "tfoot":
+			footer = append(footer, sectionRows(child, false)...)
+		case "tr":
+			rows = append(rows, tableRow{cells: rowCells(child)})
+		}
+	}
+	rows = append(rows, footer...)
+
+	grid := expandSpans(rows)
+
+	headerRows := options.headerRows
+	if headerRows < 0 {
+		headerRows = detectHeaderRows(rows)
+	}
+	headerRows = min(headerRows, len(grid))
+
+	if headerRows > 0 {
+		table.Header = joinHeaderRows(grid[:headerRows])
+	}
+	table.Rows = grid[headerRows:]
+
+	return table
+}
+
+func sectionRows(section *html.Node, header bool) []tableRow {
+	var rows []tableRow
+	for _, child := range childNodes(section) {
+		if child.Type == html.ElementNode && child.Data == "tr" {
+			rows = append(rows, tableRow{cells: rowCells(child), header: header})
+		}
+	}
+	return rows
+}
+
+func rowCells(tr *html.Node) []*html.Node {
+	var cells []*html.Node
+	for _, child := range childNodes(tr) {
+		if child.Type == html.ElementNode && (child.Data == "td" || child.Data == "th") {
+			cells = append(cells, child)
+		}
+	}
+	return cells
+}
+
+// The `thead` rows, or the first row if all of its cells are `th`.
+func detectHeaderRows(rows []tableRow) int {
+	n := 0
+	for n < len(rows) && rows[n].header {
+		n++
+	}
+	if n > 0 || len(rows) == 0 || len(rows[0].cells) == 0 {
+		return n
+	}
+
+	for _, cell := range rows[0].cells {
+		if cell.Data != "th" {
+			return 0
+		}
+	}
+	return 1
+}
+
+// Lays out the cells in a grid, repeating the spanning cells.
+func expandSpans(rows []tableRow) [][]string {
+	grid := make([][]string, len(rows))
+	filled := make([][]bool, len(rows))
+
+	place := func(row, col int, text string) {
+		for len(grid[row]) <= col {
+			grid[row] = append(grid[row], "")
+			filled[row] = append(filled[row], false)
+		}
+		grid[row][col] = text
+		filled[row][col] = true
+	}
+
+	width := 0
+	for r, row := range rows {
+		col := 0
+		for _, cell := range row.cells {
+			// Skip the columns occupied by the rowspans from above
+			for col < len(filled[r]) && filled[r][col] {
+				col++
+			}
+
+			text := transform.NormalizeWhitespace(textContent(cell))
+			colspan := span(cell, "colspan", 1)
+			rowspan := span(cell, "rowspan", len(rows)-r)
+
+			for dr := range min(rowspan, len(rows)-r) {
+				for dc := range colspan {
+					place(r+dr, col+dc, text)
+				}
+			}
+			col += colspan
+		}
+		width = max(width, len(grid[r]))
+	}
+
+	for r := range grid {
+		for len(grid[r]) < width {
+			grid[r] = append(grid[r], "")
+		}
+	}
+	return grid
+}
+
+// Parses the span attribute, zero rowspan spans the remaining rows.
+func span(cell *html.Node, name string, remaining int) int {
+	value, err := strconv.Atoi(strings.TrimSpace(attr(cell, name)))
+	switch {
+	case err != nil || value < 0:
+		return 1
+	case value == 0 && name == "rowspan":
+		return max(remaining, 1)
+	case value == 0:
+		return 1
+	default:
+		return min(value, maxTableSpan)
+	}
+}
+
+// Joins the header rows per column, skipping the repetitions of the spanning cells.
+func joinHeaderRows(rows [][]string) []string {
+	header := make([]string, len(rows[0]))
+	for col := range header {
+		var parts []string
+		for _, row := range rows {
+			if text := row[col]; text != "" && (len(parts) == 0 || parts[len(parts)-1] != text) {
+				parts = append(parts, text)
+			}
+		}
+		header[col] = strings.Join(parts, " ")
+	}
+	return header
+}
@@ -0,0 +1,77 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTables(t *testing.T) {
+	page := `<html><body>
+		<table id="prices">
+			<caption> Plans </caption>
+			<thead>
+				<tr><th rowspan="2">Plan</th><th colspan="2">Price</th></tr>
+				<tr><th>Monthly</th><th>Yearly</th></tr>
+			</thead>
+			<tbody>
+				<tr><td>Basic</td><td>5</td><td>50</td></tr>
+				<tr><td rowspan="2">Pro, "team"</td><td colspan="2">Contact us</td></tr>
+				<tr><td>20</td><td>200</td></tr>
+			</tbody>
+			<tfoot><tr><td colspan="3">Prices in USD</td></tr></tfoot>
+		</table>
+
+		<table id="plain">
+			<tr><th>Name</th><th>Name</th><th></th></tr>
+			<tr><td>a</td><td>b<table><tr><td>nested</td></tr></table></td><td>c</td></tr>
+			<tr><td>d</td></tr>
+		</table>
+	</body></html>`
+
+	doc, err := NewElement(strings.NewReader(page))
+	require.NoError(t, err)
+
+	tables := doc.Tables()
+	require.Len(t, tables, 3, "nested tables are extracted separately")
+
+	prices := tables[0]
+	assert.Equal(t, "Plans", prices.Caption)
+	assert.Equal(t, []string{"Plan", "Price Monthly", "Price Yearly"}, prices.Header)
+	assert.Equal(t, [][]string{
+		{"Basic", "5", "50"},
+		{`Pro, "team"`, "Contact us", "Contact us"},
+		{`Pro, "team"`, "20", "200"},
+		{"Prices in USD", "Prices in USD", "Prices in USD"},
+	}, prices.Rows)
+
+	t.Run("maps", func(t *testing.T) {
+		maps := tables[1].Maps()
+		require.Len(t, maps, 2)
+		assert.Equal(t, map[string]string{"Name": "a", "Name 2": "bnested", "3": "c"}, maps[0])
+		assert.Equal(t, map[string]string{"Name": "d", "Name 2": "", "3": ""}, maps[1])
+
+		assert.Equal(t, []map[string]string{{"1": "nested"}}, tables[2].Maps())
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		var b strings.Builder
+		require.NoError(t, prices.WriteCSV(&b))
+		assert.Equal(t, "Plan,Price Monthly,Price Yearly\nBasic,5,50\n\"Pro, \"\"team\"\"\",Contact us,Contact us\n\"Pro, \"\"team\"\"\",20,200\nPrices in USD,Prices in USD,Prices in USD\n", b.String())
+	})
+
+	t.Run("header rows", func(t *testing.T) {
+		table := doc.Find("#prices").Tables(WithHeaderRows(0))[0]
+		assert.Nil(t, table.Header)
+		assert.Len(t, table.Rows, 6)
+
+		table = doc.Find("#plain").Tables(WithHeaderRows(2))[0]
+		assert.Equal(t, []string{"Name a", "Name bnested", "c"}, table.Header)
+		assert.Equal(t, [][]string{{"d", "", ""}}, table.Rows)
+	})
+}
@@ -0,0 +1,93 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package transform
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Layouts tried by [ParseDate] without the explicit ones. The numeric
+// day/month layouts (eg: "02/01/2006") are ambiguous, hence not included.
+var DateLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"2006/01/02",
+	"2006.01.02",
+	"20060102",
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC850,
+	time.RFC822Z,
+	time.RFC822,
+	time.ANSIC,
+	"Monday, January 2, 2006 3:04 PM",
+	"Monday, January 2, 2006",
+	"Mon, January 2, 2006",
+	"Mon, Jan 2, 2006",
+	"January 2, 2006 3:04 PM",
+	"January 2, 2006 15:04",
+	"January 2, 2006",
+	"Jan 2, 2006 3:04 PM",
+	"Jan 2, 2006",
+	"Jan. 2, 2006",
+	"2 January 2006 15:04",
+	"2 January 2006",
+	"2 Jan 2006 15:04",
+	"2 Jan 2006",
+	"Monday 2 January 2006",
+	"January 2006",
+	"Jan 2006",
+}
+
+var (
+	// Ordinal suffixes of the days (eg: "3rd")
+	ordinalDay = regexp.MustCompile(`\b(\d{1,2})(st|nd|rd|th)\b`)
+
+	// Connectives between the date and time (eg: "March 3, 2026 at 10:00")
+	dateConnective = regexp.MustCompile(`(?i)\s+(at|@)\s+`)
+
+	unixTimestamp = regexp.MustCompile(`^\d{10}(\d{3})?$`)
+)
+
+// Parses the date with the layouts (see [time.Parse]), or with [DateLayouts]
+// if none are given, along with the Unix timestamps in seconds or
+// milliseconds. The ordinal days (eg: "March 3rd") are supported as well.
+// The dates without the timezone are in UTC.
+func ParseDate(layouts ...string) TypedTransformer[time.Time] {
+	if len(layouts) == 0 {
+		layouts = DateLayouts
+	}
+
+	return func(text string) (time.Time, error) {
+		s := NormalizeWhitespace(text)
+		s = ordinalDay.ReplaceAllString(s, "$1")
+		s = dateConnective.ReplaceAllString(s, " ")
+
+		for _, layout := range layouts {
+			if t, err := time.Parse(layout, s); err == nil {
+				return t, nil
+			}
+		}
+
+		if unixTimestamp.MatchString(s) {
+			n, _ := strconv.ParseInt(s, 10, 64)
+			if len(s) == 13 {
+				return time.UnixMilli(n).UTC(), nil
+			}
+			return time.Unix(n, 0).UTC(), nil
+		}
+
+		return time.Time{}, fmt.Errorf("%w %q", ErrInvalidDate, strings.TrimSpace(text))
+	}
+}
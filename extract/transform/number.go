@@ -0,0 +1,248 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package transform
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// NumberFormat is the decimal and the grouping separators of a locale.
+type NumberFormat struct {
+	// Grouping separators (eg: ',' for "1,000"), any one of them is accepted,
+	// the groups following it must be of three digits
+	Group []rune
+
+	Decimal rune
+}
+
+// The grouping spaces include the no-break (U+00A0) and narrow no-break (U+202F) ones.
+var (
+	commaDecimal = NumberFormat{Decimal: ',', Group: []rune{'.', ' ', '\u00a0', '\u202f'}}
+	spaceGroup   = NumberFormat{Decimal: ',', Group: []rune{' ', '\u00a0', '\u202f', '.'}}
+	swissFormat  = NumberFormat{Decimal: '.', Group: []rune{'\'', '\u2019', ' ', '\u00a0'}}
+	dotDecimal   = NumberFormat{Decimal: '.', Group: []rune{',', ' ', '\u00a0', '\u202f'}}
+)
+
+// Number formats by the language (or language-region) tag, the rest use dotDecimal.
+var numberFormats = map[string]NumberFormat{
+	"de": commaDecimal, "es": commaDecimal, "it": commaDecimal, "nl": commaDecimal,
+	"pt": commaDecimal, "id": commaDecimal, "tr": commaDecimal, "da": commaDecimal,
+	"el": commaDecimal, "ro": commaDecimal, "hr": commaDecimal, "sl": commaDecimal,
+	"vi": commaDecimal,
+	"fr": spaceGroup, "ru": spaceGroup, "pl": spaceGroup, "cs": spaceGroup, "sk": spaceGroup,
+	"sv": spaceGroup, "nb": spaceGroup, "no": spaceGroup, "fi": spaceGroup, "uk": spaceGroup,
+	"hu": spaceGroup, "bg": spaceGroup,
+	"de-ch": swissFormat, "it-ch": swissFormat, "fr-ch": swissFormat,
+}
+
+// Number format of the locale (eg: "de", "fr-CA", "en_US"), as per the
+// language-region tag, then the language, defaults to "1,234.5".
+func LocaleNumberFormat(locale string) NumberFormat {
+	tag := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+	if f, ok := numberFormats[tag]; ok {
+		return f
+	}
+	language, _, _ := strings.Cut(tag, "-")
+	if f, ok := numberFormats[language]; ok {
+		return f
+	}
+	return dotDecimal
+}
+
+// Parses the number formatted as per the locale (eg: "1.234,5" for "de"),
+// with the negatives as "-1", "−1", "1-" or "(1)".
+func ParseNumber(locale string) TypedTransformer[float64] {
+	format := LocaleNumberFormat(locale)
+	return func(text string) (float64, error) {
+		return format.Parse(text)
+	}
+}
+
+func (f NumberFormat) Parse(text string) (float64, error) {
+	s := strings.TrimSpace(text)
+
+	negative := false
+	switch {
+	case strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")"):
+		negative, s = true, s[1:len(s)-1]
+	case strings.HasPrefix(s, "-"), strings.HasPrefix(s, "−"):
+		negative = true
+		s = strings.TrimLeft(s, "-−")
+	case strings.HasSuffix(s, "-"):
+		negative, s = true, s[:len(s)-1]
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	}
+	s = strings.TrimSpace(s)
+
+	var b strings.Builder
+	decimal := false
+
+	// Separator of the groups (if any) and the digits of the current group
+	var group rune
+	digits := 0
+	grouping := func() error {
+		if group != 0 && digits != 3 {
+			return fmt.Errorf("%w %q: invalid digit grouping", ErrInvalidNumber, text)
+		}
+		return nil
+	}
+
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+			digits++
+		case r == f.Decimal && !decimal:
+			if err := grouping(); err != nil {
+				return 0, err
+			}
+			decimal = true
+			b.WriteByte('.')
+		case slices.Contains(f.Group, r) && !decimal && digits > 0 && (group == 0 || group == r):
+			if err := grouping(); err != nil {
+				return 0, err
+			}
+			if group == 0 && digits > 3 {
+				return 0, fmt.Errorf("%w %q: invalid digit grouping", ErrInvalidNumber, text)
+			}
+			group, digits = r, 0
+		default:
+			return 0, fmt.Errorf("%w %q: unexpected %q", ErrInvalidNumber, text, r)
+		}
+	}
+	if !decimal {
+		if err := grouping(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := strconv.ParseFloat(b.String(), 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w %q: %w", ErrInvalidNumber, text, err)
+	}
+	if negative {
+		n = -n
+	}
+	return n, nil
+}
+
+// Money is an amount along with its ISO 4217 currency code.
+type Money struct {
+	// ISO 4217 code (eg: "EUR"), empty if the text has no currency
+	Currency string
+
+	Amount float64
+}
+
+// Currency symbols, the longer ones are matched first (eg: "R$" before "$").
+var currencySymbols = map[string]string{
+	"US$": "USD", "C$": "CAD", "CA$": "CAD", "A$": "AUD", "AU$": "AUD", "NZ$": "NZD",
+	"HK$": "HKD", "S$": "SGD", "R$": "BRL", "MX$": "MXN",
+	"€": "EUR", "£": "GBP", "¥": "JPY", "₹": "INR", "₽": "RUB", "₩": "KRW", "₺": "TRY",
+	"₪": "ILS", "₫": "VND", "฿": "THB", "₴": "UAH", "₦": "NGN", "₱": "PHP",
+	"zł": "PLN", "Kč": "CZK", "Fr.": "CHF", "kr": "SEK",
+}
+
+// Currency of "$" and "kr" by the locale, defaults to USD and SEK.
+var localeCurrencies = map[string]map[string]string{
+	"$":  {"en-ca": "CAD", "fr-ca": "CAD", "en-au": "AUD", "en-nz": "NZD", "es-mx": "MXN", "en-sg": "SGD", "zh-hk": "HKD", "es-ar": "ARS", "es-cl": "CLP", "es-co": "COP"},
+	"kr": {"da": "DKK", "nb": "NOK", "no": "NOK", "is": "ISK"},
+	"¥":  {"zh": "CNY"},
+}
+
+var (
+	symbolsByLength = func() []string {
+		symbols := make([]string, 0, len(currencySymbols)+1)
+		for symbol := range currencySymbols {
+			symbols = append(symbols, symbol)
+		}
+		symbols = append(symbols, "$")
+		slices.SortFunc(symbols, func(a, b string) int {
+			return len(b) - len(a)
+		})
+		return symbols
+	}()
+
+	currencyCode = regexp.MustCompile(`^[A-Z]{3}$`)
+)
+
+// Parses the price (eg: "$1,299.00", "1.299,00 €", "CHF 12.50", "-£5", "(£5)"),
+// the amount as per the locale, and the currency from the symbol or the ISO
+// code preceding or following it.
+func ParseCurrency(locale string) TypedTransformer[Money] {
+	format := LocaleNumberFormat(locale)
+	tag := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+
+	return func(text string) (Money, error) {
+		s := strings.TrimSpace(text)
+
+		sign := ""
+		if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+			// Accounting negative (eg: "(£5)")
+			sign, s = "-", strings.TrimSpace(s[1:len(s)-1])
+		} else if rest, ok := strings.CutPrefix(s, "-"); ok {
+			sign, s = "-", strings.TrimSpace(rest)
+		} else if rest, ok := strings.CutPrefix(s, "−"); ok {
+			sign, s = "-", strings.TrimSpace(rest)
+		}
+
+		symbol, amount := cutCurrency(s)
+		money := Money{Currency: currencyOf(symbol, tag)}
+
+		n, err := format.Parse(sign + amount)
+		if err != nil {
+			return Money{}, err
+		}
+		money.Amount = n
+		return money, nil
+	}
+}
+
+// Splits the currency symbol (or code) before or after the amount.
+func cutCurrency(s string) (symbol, amount string) {
+	for _, symbol := range symbolsByLength {
+		if rest, ok := strings.CutPrefix(s, symbol); ok {
+			return symbol, strings.TrimSpace(rest)
+		}
+		if rest, ok := strings.CutSuffix(s, symbol); ok {
+			return symbol, strings.TrimSpace(rest)
+		}
+	}
+
+	if fields := strings.FieldsFunc(s, unicode.IsSpace); len(fields) > 1 {
+		if first := fields[0]; currencyCode.MatchString(first) {
+			return first, strings.TrimSpace(s[len(first):])
+		}
+		if last := fields[len(fields)-1]; currencyCode.MatchString(last) {
+			return last, strings.TrimSpace(s[:len(s)-len(last)])
+		}
+	}
+	return "", s
+}
+
+func currencyOf(symbol, locale string) string {
+	if symbol == "" || currencyCode.MatchString(symbol) {
+		return symbol
+	}
+
+	if byLocale, ok := localeCurrencies[symbol]; ok {
+		language, _, _ := strings.Cut(locale, "-")
+		if code, ok := byLocale[locale]; ok {
+			return code
+		}
+		if code, ok := byLocale[language]; ok {
+			return code
+		}
+	}
+	if symbol == "$" {
+		return "USD"
+	}
+	return currencySymbols[symbol]
+}
@@ -0,0 +1,42 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package transform
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Replaces the matches of the expression, the replacement can refer to the
+// groups (eg: "$1", "${name}"), see [regexp.Regexp.ReplaceAllString].
+func ReplaceAll(re *regexp.Regexp, replacement string) Transformer {
+	return func(text string) string {
+		return re.ReplaceAllString(text, replacement)
+	}
+}
+
+// Group of the first match (0 for the whole match), or empty if none.
+// See [Match] to fail instead.
+func Capture(re *regexp.Regexp, group int) Transformer {
+	return func(text string) string {
+		value, _ := Match(re, group)(text)
+		return value
+	}
+}
+
+// Group of the first match (0 for the whole match), fails with [ErrNoMatch]
+// if the expression (or the group) doesn't match.
+//
+// # Example Usage
+//
+//	sku := transform.Match(regexp.MustCompile(`SKU:\s*(\w+)`), 1)
+func Match(re *regexp.Regexp, group int) TypedTransformer[string] {
+	return func(text string) (string, error) {
+		match := re.FindStringSubmatchIndex(text)
+		if match == nil || 2*group+1 >= len(match) || match[2*group] < 0 {
+			return "", fmt.Errorf("%w: %q in %q", ErrNoMatch, re.String(), text)
+		}
+		return text[match[2*group]:match[2*group+1]], nil
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package transform
+
+import (
+	"html"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Decodes the HTML entities (eg: "&amp;", "&#39;", "&eacute;").
+// The text of the elements is already decoded, it's meant for the attributes
+// and the text which was escaped twice.
+var DecodeEntities Transformer = html.UnescapeString
+
+// Unicode normalization forms, see https://unicode.org/reports/tr15.
+// NFC composes the characters (eg: "e" + U+0301 to "é"), while NFKC also
+// replaces the compatibility characters (eg: "ﬁ" to "fi", full-width digits).
+var (
+	NFC  Transformer = norm.NFC.String
+	NFD  Transformer = norm.NFD.String
+	NFKC Transformer = norm.NFKC.String
+	NFKD Transformer = norm.NFKD.String
+)
+
+// Truncates the text to at most limit characters (runes), including the
+// suffix (eg: "…"), at the last word boundary if there's one in the second
+// half. The text within the limit is returned as is.
+func Truncate(limit int, suffix string) Transformer {
+	return func(text string) string {
+		runes := []rune(text)
+		if limit <= 0 {
+			return ""
+		}
+		if len(runes) <= limit {
+			return text
+		}
+
+		keep := limit - len([]rune(suffix))
+		if keep <= 0 {
+			return string([]rune(suffix)[:limit])
+		}
+
+		cut := keep
+		for i := keep; i > keep/2; i-- {
+			if unicode.IsSpace(runes[i]) {
+				cut = i
+				break
+			}
+		}
+		return strings.TrimRightFunc(string(runes[:cut]), unicode.IsSpace) + suffix
+	}
+}
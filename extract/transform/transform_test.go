@@ -0,0 +1,171 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package transform
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegexp(t *testing.T) {
+	sku := regexp.MustCompile(`SKU:\s*(\w+)(-(\d+))?`)
+
+	assert.Equal(t, "a b c", ReplaceAll(regexp.MustCompile(`[\s_]+`), " ")("a_ b__c"))
+	assert.Equal(t, "2026-03", ReplaceAll(regexp.MustCompile(`(\d+)/(\d+)`), "$2-$1")("03/2026"))
+	assert.Equal(t, "AB12", Capture(sku, 1)("Item SKU: AB12"))
+	assert.Empty(t, Capture(sku, 1)("no sku"))
+
+	_, err := Match(sku, 3)("SKU: AB12")
+	assert.ErrorIs(t, err, ErrNoMatch, "optional group didn't participate")
+	_, err = Match(sku, 9)("SKU: AB12")
+	assert.ErrorIs(t, err, ErrNoMatch)
+
+	value, err := Match(sku, 3)("SKU: AB12-7")
+	require.NoError(t, err)
+	assert.Equal(t, "7", value)
+}
+
+func TestText(t *testing.T) {
+	assert.Equal(t, `Tom & "Jerry" é`, DecodeEntities("Tom &amp; &quot;Jerry&quot; &eacute;"))
+
+	decomposed := "é"
+	assert.Equal(t, "é", NFC(decomposed))
+	assert.Equal(t, decomposed, NFD("é"))
+	assert.Equal(t, "fi 12", NFKC("ﬁ １２"))
+
+	truncate := Truncate(16, "…")
+	assert.Equal(t, "short", truncate("short"))
+	assert.Equal(t, "The quick brown…", truncate("The quick brown fox jumps"))
+	assert.Equal(t, "Supercalifragil…", truncate("Supercalifragilisticexpialidocious"))
+	assert.Equal(t, "ñññ…", Truncate(4, "…")("ñññññ"), "counts runes")
+	assert.Empty(t, Truncate(0, "…")("text"))
+}
+
+func TestNumbers(t *testing.T) {
+	tests := []struct {
+		locale string
+		text   string
+		want   float64
+	}{
+		{"en", "1,234,567.89", 1234567.89},
+		{"en-US", " +42 ", 42},
+		{"de", "1.234,5", 1234.5},
+		{"de_DE", "-0,75", -0.75},
+		{"fr", "1 234,5", 1234.5},
+		{"fr-FR", "12 000", 12000},
+		{"de-CH", "1'234.50", 1234.5},
+		{"en", "(12.5)", -12.5},
+		{"en", "12.5-", -12.5},
+		{"en", "1234.5", 1234.5},
+		{"en", "12,345.678", 12345.678},
+	}
+	for _, tt := range tests {
+		got, err := ParseNumber(tt.locale)(tt.text)
+		require.NoError(t, err, "%s %q", tt.locale, tt.text)
+		assert.InDelta(t, tt.want, got, 1e-9, "%s %q", tt.locale, tt.text)
+	}
+
+	for _, text := range []string{"", "12a", "1.2.3", ",5", "1,2,3", "1,23", "1234,567", "1,234 567", "1,234,5678.9"} {
+		_, err := ParseNumber("en")(text)
+		assert.ErrorIs(t, err, ErrInvalidNumber, "%q", text)
+	}
+
+	n, err := ParseInt(" 42 ")
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), n)
+	_, err = ParseFloat("4,2")
+	assert.ErrorIs(t, err, ErrInvalidNumber)
+}
+
+func TestCurrency(t *testing.T) {
+	tests := []struct {
+		locale string
+		text   string
+		want   Money
+	}{
+		{"en", "$1,299.00", Money{Currency: "USD", Amount: 1299}},
+		{"en-CA", "$5", Money{Currency: "CAD", Amount: 5}},
+		{"de", "1.299,99 €", Money{Currency: "EUR", Amount: 1299.99}},
+		{"pt-BR", "R$ 10,50", Money{Currency: "BRL", Amount: 10.5}},
+		{"en", "-£5.25", Money{Currency: "GBP", Amount: -5.25}},
+		{"en", "(£5)", Money{Currency: "GBP", Amount: -5}},
+		{"en", "($1,000.00)", Money{Currency: "USD", Amount: -1000}},
+		{"de-CH", "CHF 1'000.50", Money{Currency: "CHF", Amount: 1000.5}},
+		{"en", "12.99 USD", Money{Currency: "USD", Amount: 12.99}},
+		{"da", "100 kr", Money{Currency: "DKK", Amount: 100}},
+		{"en", "19.90", Money{Amount: 19.9}},
+	}
+	for _, tt := range tests {
+		got, err := ParseCurrency(tt.locale)(tt.text)
+		require.NoError(t, err, "%s %q", tt.locale, tt.text)
+		assert.Equal(t, tt.want.Currency, got.Currency, "%s %q", tt.locale, tt.text)
+		assert.InDelta(t, tt.want.Amount, got.Amount, 1e-9, "%s %q", tt.locale, tt.text)
+	}
+
+	_, err := ParseCurrency("en")("free")
+	assert.ErrorIs(t, err, ErrInvalidNumber)
+}
+
+func TestDates(t *testing.T) {
+	parse := ParseDate()
+	tests := map[string]time.Time{
+		"2026-03-14T08:30:00Z":          time.Date(2026, 3, 14, 8, 30, 0, 0, time.UTC),
+		"2026-03-14":                    time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC),
+		"March 3rd, 2026":               time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC),
+		"Tuesday, March 3, 2026":        time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC),
+		"3 Mar   2026":                  time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC),
+		"March 3, 2026 at 4:05 PM":      time.Date(2026, 3, 3, 16, 5, 0, 0, time.UTC),
+		"Tue, 03 Mar 2026 10:00:00 GMT": time.Date(2026, 3, 3, 10, 0, 0, 0, time.UTC),
+		"1772539200":                    time.Date(2026, 3, 3, 12, 0, 0, 0, time.UTC),
+		"1772539200000":                 time.Date(2026, 3, 3, 12, 0, 0, 0, time.UTC),
+	}
+	for text, want := range tests {
+		got, err := parse(text)
+		require.NoError(t, err, text)
+		assert.True(t, want.Equal(got), "%q: %s", text, got)
+	}
+
+	_, err := parse("03/04/2026")
+	assert.ErrorIs(t, err, ErrInvalidDate, "ambiguous without the layout")
+
+	got, err := ParseDate("02/01/2006")("03/04/2026")
+	require.NoError(t, err)
+	assert.Equal(t, time.April, got.Month())
+}
+
+func TestURLs(t *testing.T) {
+	assert.Equal(t, "https://example.com/a?id=7&q=go", CleanURL(" HTTPS://Example.COM:443/a?utm_source=x&q=go&id=7&fbclid=1#top "))
+	assert.Equal(t, "http://example.com/", CleanURL("http://example.com:80"))
+	assert.Equal(t, "http://[::1]:8080/", CleanURL("http://[::1]:8080"))
+	assert.Equal(t, "not a url", CleanURL("not a url"))
+	assert.Equal(t, "https://x.com/search?page=2&q=50%off", CleanURL("https://x.com/search?q=50%off&page=2&utm_medium=email"))
+	assert.Equal(t, "https://x.com/p?a=1;b=2", CleanURL("https://x.com/p?a=1;b=2"))
+	assert.Equal(t, "https://x.com/p?a=+&b=%20", CleanURL("https://x.com/p?b=%20&a=+"), "the keys sort the pairs, the values are kept")
+	assert.Equal(t, "HTTPS://X.com/p?%zz=1#top", CleanURL("HTTPS://X.com/p?%zz=1#top"), "unparsable query")
+
+	absolute := AbsoluteURL("https://example.com/blog/post")
+	assert.Equal(t, "https://example.com/img/a.png", absolute("../img/a.png"))
+	assert.Equal(t, "https://cdn.example.net/x", absolute("//cdn.example.net/x"))
+
+	u, err := ParseURL("https://Example.com/p?gclid=1")
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/p", u.String())
+
+	_, err = ParseURL("/relative")
+	assert.ErrorIs(t, err, ErrInvalidURL)
+}
+
+func TestApplyTyped(t *testing.T) {
+	price, err := ApplyTyped("  Price:  1.299,00 €  ", ParseCurrency("de"), ReplaceAll(regexp.MustCompile(`^\s*Price:`), ""), NormalizeWhitespace)
+	require.NoError(t, err)
+	assert.Equal(t, Money{Currency: "EUR", Amount: 1299}, price)
+
+	var typed TypedTransformer[int64] = ParseInt
+	_, err = ApplyTyped("n/a", typed)
+	assert.ErrorIs(t, err, ErrInvalidNumber)
+}
@@ -0,0 +1,51 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package transform
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrNoMatch       = errors.New("transform: no match")
+	ErrInvalidNumber = errors.New("transform: invalid number")
+	ErrInvalidDate   = errors.New("transform: invalid date")
+	ErrInvalidURL    = errors.New("transform: invalid url")
+)
+
+// TypedTransformer converts the text into a typed value, unlike [Transformer]
+// it reports the failures (eg: malformed number) instead of passing the text
+// through.
+type TypedTransformer[T any] func(string) (T, error)
+
+// Applies the transformers on the text, then converts it via the typed transformer.
+//
+// # Example Usage
+//
+//	price, err := transform.ApplyTyped(" 1.299,00 € ", transform.ParseCurrency("de"), transform.NormalizeWhitespace)
+func ApplyTyped[T any](text string, typed TypedTransformer[T], transformers ...Transformer) (T, error) {
+	return typed(ApplyTransformations(text, transformers...))
+}
+
+// Parses the base 10 integer, surrounded by whitespace.
+func ParseInt(text string) (int64, error) {
+	n, err := strconv.ParseInt(strings.TrimSpace(text), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w %q: %w", ErrInvalidNumber, text, err)
+	}
+	return n, nil
+}
+
+// Parses the floating point number, surrounded by whitespace.
+// See [ParseNumber] for the locale-specific separators.
+func ParseFloat(text string) (float64, error) {
+	f, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w %q: %w", ErrInvalidNumber, text, err)
+	}
+	return f, nil
+}
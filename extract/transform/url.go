@@ -0,0 +1,136 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package transform
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// Query parameters which only track the visits (eg: "utm_source"),
+// the ones ending with "*" are prefixes.
+var TrackingParams = []string{
+	"utm_*", "fbclid", "gclid", "gclsrc", "dclid", "msclkid", "yclid", "twclid",
+	"mc_cid", "mc_eid", "_ga", "_gl", "_hsenc", "_hsmi", "igshid", "mkt_tok",
+	"ref_src", "spm", "vero_id", "oly_anon_id", "oly_enc_id",
+}
+
+// Normalizes the URL: lowercases the scheme and host, removes the default
+// port, the fragment and the tracking parameters (see [TrackingParams]), and
+// sorts the query by the keys. The other parameters are kept as is, the URL
+// is returned unchanged if its query cannot be parsed. The text is returned
+// as is (trimmed) if it isn't a URL.
+func CleanURL(text string) string {
+	raw := strings.TrimSpace(text)
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" || !cleanURL(u) {
+		return raw
+	}
+	return u.String()
+}
+
+// Resolves the (relative) URL against the base (eg: the page URL).
+// The text is returned as is (trimmed) if either isn't a URL.
+func AbsoluteURL(base string) Transformer {
+	baseURL, baseErr := url.Parse(base)
+	return func(text string) string {
+		raw := strings.TrimSpace(text)
+		if baseErr != nil {
+			return raw
+		}
+		u, err := baseURL.Parse(raw)
+		if err != nil {
+			return raw
+		}
+		return u.String()
+	}
+}
+
+// Parses the absolute URL, cleaned up as per [CleanURL].
+func ParseURL(text string) (*url.URL, error) {
+	u, err := url.Parse(strings.TrimSpace(text))
+	if err != nil {
+		return nil, fmt.Errorf("%w %q: %w", ErrInvalidURL, text, err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("%w %q: not absolute", ErrInvalidURL, text)
+	}
+	cleanURL(u)
+	return u, nil
+}
+
+// Cleans up the URL in place, returns false (leaving it as is) if its query
+// cannot be parsed.
+func cleanURL(u *url.URL) bool {
+	query, ok := cleanQuery(u.RawQuery)
+	if !ok {
+		return false
+	}
+	u.RawQuery = query
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	if port := u.Port(); (u.Scheme == "http" && port == "80") || (u.Scheme == "https" && port == "443") {
+		u.Host = u.Hostname()
+		if strings.Contains(u.Host, ":") {
+			// IPv6
+			u.Host = "[" + u.Host + "]"
+		}
+	}
+	if u.Path == "" {
+		u.Path = "/"
+	}
+	u.Fragment = ""
+	u.RawFragment = ""
+	return true
+}
+
+// Removes the tracking parameters from the raw query and sorts the rest by
+// the keys, without re-encoding them. Returns false if a key cannot be decoded.
+func cleanQuery(raw string) (string, bool) {
+	type param struct {
+		key, raw string
+	}
+
+	var params []param
+	for pair := range strings.SplitSeq(raw, "&") {
+		if pair == "" {
+			continue
+		}
+		rawKey, _, _ := strings.Cut(pair, "=")
+		key, err := url.QueryUnescape(rawKey)
+		if err != nil {
+			return raw, false
+		}
+		if !isTrackingParam(key) {
+			params = append(params, param{key: key, raw: pair})
+		}
+	}
+
+	slices.SortStableFunc(params, func(a, b param) int {
+		return strings.Compare(a.key, b.key)
+	})
+
+	pairs := make([]string, len(params))
+	for i, p := range params {
+		pairs[i] = p.raw
+	}
+	return strings.Join(pairs, "&"), true
+}
+
+func isTrackingParam(key string) bool {
+	key = strings.ToLower(key)
+	for _, param := range TrackingParams {
+		if prefix, ok := strings.CutSuffix(param, "*"); ok {
+			if strings.HasPrefix(key, prefix) {
+				return true
+			}
+		} else if key == param {
+			return true
+		}
+	}
+	return false
+}
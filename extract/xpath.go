@@ -0,0 +1,63 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package parser
+
+import (
+	"github.com/antchfx/htmlquery"
+	"github.com/antchfx/xpath"
+	"github.com/ritvikos/synapse/extract/transform"
+	"golang.org/x/net/html"
+)
+
+// Reports whether the XPath expression is valid, as the XPath methods
+// (like the CSS ones) match nothing for the invalid expressions.
+func ValidateXPath(expr string) error {
+	_, err := xpath.Compile(expr)
+	return err
+}
+
+// XPath equivalent of [Element.Find], evaluated with each node of the element
+// as the root (i.e. "//a" matches the descendants, not the whole document).
+//
+// The attribute (eg: "//a/@href") and text (eg: "//p/text()") nodes can be
+// selected as well, their text is the value.
+//
+// The result is an Element as well, so that the XPath and CSS can be mixed:
+//
+//	doc.FindXPath("//article[@data-id]").Find("h1.title").Text()
+func (e *Element) FindXPath(expr string) *Element {
+	// Unlike Slice(0, 0), the empty selection doesn't share the backing
+	// array of the element, which AddNodes would overwrite otherwise.
+	return newElementFromSelection(e.dom.FindNodes().AddNodes(e.queryXPath(expr)...))
+}
+
+func (e *Element) CollectXPath(expr string, transformers ...transform.Transformer) string {
+	text := e.FindXPath(expr).RawText()
+	return transform.ApplyTransformations(text, transformers...)
+}
+
+func (e *Element) CollectAllXPath(expr string, transformers ...transform.Transformer) []string {
+	return e.IterXPath(expr).Map(func(e *Element) string {
+		return transform.ApplyTransformations(e.Text(), transformers...)
+	})
+}
+
+func (e *Element) IterXPath(expr string) *elementIter {
+	return &elementIter{
+		dom: e.FindXPath(expr).dom,
+	}
+}
+
+func (e *Element) queryXPath(expr string) []*html.Node {
+	compiled, err := xpath.Compile(expr)
+	if err != nil {
+		return nil
+	}
+
+	var nodes []*html.Node
+	for _, node := range e.dom.Nodes {
+		nodes = append(nodes, htmlquery.QuerySelectorAll(node, compiled)...)
+	}
+	return nodes
+}
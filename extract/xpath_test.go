@@ -0,0 +1,52 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ritvikos/synapse/extract/transform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestXPath(t *testing.T) {
+	page := `<html><body>
+		<article data-id="1"><h1 class="title"> First </h1><a href="/one">One</a></article>
+		<article><h1 class="title">Draft</h1></article>
+		<article data-id="3"><h1 class="title">Third</h1><a href="/three">Three</a><p>Body <b>text</b></p></article>
+	</body></html>`
+
+	doc, err := NewElement(strings.NewReader(page))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"First", "Third"}, doc.CollectAllXPath("//article[@data-id]/h1"))
+	assert.Equal(t, []string{"/one", "/three"}, doc.CollectAllXPath("//a/@href"), "attribute nodes")
+	assert.Equal(t, "Body ", doc.CollectXPath("//article[3]/p/text()"), "text nodes")
+	assert.Equal(t, "FIRST", doc.CollectXPath("(//h1)[1]", transform.TrimSpace, transform.ToUpper))
+
+	t.Run("mixed with css", func(t *testing.T) {
+		titles := doc.FindXPath("//article[a]").CollectAll("h1.title")
+		assert.Equal(t, []string{"First", "Third"}, titles)
+
+		third := doc.Find("article:last-child")
+		assert.Equal(t, "Three", third.CollectXPath("//a"), "scoped to the element")
+		assert.Equal(t, []string{"Three"}, third.CollectAllXPath(".//a"))
+
+		var ids []string
+		doc.IterXPath("//article").Each(func(e *Element) {
+			if id, ok := e.Attr("data-id"); ok {
+				ids = append(ids, id)
+			}
+		})
+		assert.Equal(t, []string{"1", "3"}, ids)
+	})
+
+	t.Run("invalid expression", func(t *testing.T) {
+		require.Error(t, ValidateXPath("//a[@href"))
+		require.NoError(t, ValidateXPath("//a[@href]"))
+		assert.Empty(t, doc.CollectAllXPath("//a[@href"))
+	})
+}
@@ -0,0 +1,107 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package fetcher
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"mime"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var ErrMalformedDataURL = errors.New("data fetcher: malformed data url")
+
+// DataFetcher decodes the content embedded in `data:` URLs (RFC 2397).
+// (eg: inline images, or documents embedded in the pages)
+type DataFetcher struct{}
+
+func NewDataFetcher() *DataFetcher {
+	return &DataFetcher{}
+}
+
+func (d *DataFetcher) Fetch(ctx context.Context, u *url.URL) (*Resource, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if !strings.EqualFold(u.Scheme, "data") {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedScheme, u.Scheme)
+	}
+
+	mediaType, charset, body, err := parseDataURL(u)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Resource{
+		FetchedAt: time.Now(),
+		URL:       u,
+		MediaType: mediaType,
+		Charset:   charset,
+		Body:      body,
+	}, nil
+}
+
+// Parses `data:[<mediatype>][;base64],<data>`, the media type defaults to
+// `text/plain;charset=US-ASCII`.
+func parseDataURL(u *url.URL) (string, string, []byte, error) {
+	raw := u.Opaque
+	switch {
+	case raw == "":
+		// eg: "data:/path" is parsed as hierarchical, including the query
+		raw = strings.TrimPrefix(u.String(), u.Scheme+":")
+	case u.RawQuery != "" || u.ForceQuery:
+		// The query is part of the data, eg: "data:text/plain,a?b=c"
+		raw += "?" + u.RawQuery
+	}
+
+	header, data, ok := strings.Cut(raw, ",")
+	if !ok {
+		return "", "", nil, ErrMalformedDataURL
+	}
+
+	isBase64 := false
+	if before, found := strings.CutSuffix(header, ";base64"); found {
+		header = before
+		isBase64 = true
+	}
+
+	mediaType, charset := "text/plain", "US-ASCII"
+	if header != "" {
+		if strings.HasPrefix(header, ";") {
+			// Parameters only, eg: ";charset=utf-8"
+			header = "text/plain" + header
+		}
+
+		parsed, params, err := mime.ParseMediaType(header)
+		if err != nil {
+			return "", "", nil, fmt.Errorf("%w: %w", ErrMalformedDataURL, err)
+		}
+		mediaType, charset = parsed, params["charset"]
+	}
+
+	decoded, err := url.PathUnescape(data)
+	if err != nil {
+		return "", "", nil, fmt.Errorf("%w: %w", ErrMalformedDataURL, err)
+	}
+
+	if !isBase64 {
+		return mediaType, charset, []byte(decoded), nil
+	}
+
+	// Whitespace and missing padding are tolerated
+	decoded = strings.Join(strings.Fields(decoded), "")
+	body, err := base64.RawStdEncoding.DecodeString(strings.TrimRight(decoded, "="))
+	if err != nil {
+		return "", "", nil, fmt.Errorf("%w: %w", ErrMalformedDataURL, err)
+	}
+
+	return mediaType, charset, body, nil
+}
+
+var _ Fetcher = (*DataFetcher)(nil)
@@ -0,0 +1,112 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package fetcher
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	model "github.com/ritvikos/synapse/model"
+)
+
+var ErrUnsupportedScheme = errors.New("fetcher: unsupported scheme")
+
+// Fetcher retrieves the resource identified by the URL, regardless of the
+// scheme-specific protocol.
+type Fetcher interface {
+	Fetch(ctx context.Context, u *url.URL) (*Resource, error)
+}
+
+// Resource is the scheme-agnostic outcome of [Fetcher.Fetch].
+//
+// It can be handed over to the pipeline as is. (eg: `pipeline.Router.Dispatch`)
+type Resource struct {
+	FetchedAt time.Time
+
+	// Final URL of the resource (eg: after redirects)
+	URL *url.URL
+
+	// Scheme-specific details (eg: `*http.FetchResult` for http and https)
+	Source any
+
+	// Media type, without parameters (eg: "text/html")
+	MediaType string
+
+	// Charset of the body, if known
+	Charset string
+
+	Body []byte
+}
+
+func (r *Resource) ContentType() string {
+	return r.MediaType
+}
+
+func (r *Resource) Reader() io.Reader {
+	return bytes.NewReader(r.Body)
+}
+
+// Registry dispatches the URLs to the fetchers registered for their scheme.
+//
+// # Example Usage
+//
+//	registry := fetcher.NewRegistry()
+//	registry.Register(fetcher.NewFileFetcher("/var/lib/mirror"), "file")
+//	registry.Register(fetcher.NewDataFetcher(), "data")
+//	registry.Register(httpFetcher.AsFetcher(), "http", "https")
+//
+//	resource, err := fetcher.FetchTask(ctx, registry, task)
+type Registry struct {
+	fetchers map[string]Fetcher
+	mu       sync.RWMutex
+}
+
+func NewRegistry() *Registry {
+	return &Registry{fetchers: make(map[string]Fetcher)}
+}
+
+// Registers the fetcher for the schemes (case-insensitive), replacing the existing ones.
+func (r *Registry) Register(fetcher Fetcher, schemes ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, scheme := range schemes {
+		r.fetchers[strings.ToLower(scheme)] = fetcher
+	}
+}
+
+func (r *Registry) Lookup(scheme string) (Fetcher, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	fetcher, ok := r.fetchers[strings.ToLower(scheme)]
+	return fetcher, ok
+}
+
+// Fetches the URL via the fetcher registered for its scheme.
+func (r *Registry) Fetch(ctx context.Context, u *url.URL) (*Resource, error) {
+	fetcher, ok := r.Lookup(u.Scheme)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedScheme, u.Scheme)
+	}
+	return fetcher.Fetch(ctx, u)
+}
+
+// Fetches the URL of the frontier task via the fetcher registered for its scheme.
+func FetchTask[T any](ctx context.Context, registry *Registry, task *model.Task[T]) (*Resource, error) {
+	u, err := url.Parse(task.Url)
+	if err != nil {
+		return nil, fmt.Errorf("fetcher: invalid task url: %w", err)
+	}
+	return registry.Fetch(ctx, u)
+}
+
+var _ Fetcher = (*Registry)(nil)
@@ -0,0 +1,82 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package fetcher
+
+import (
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	model "github.com/ritvikos/synapse/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	dir := t.TempDir()
+	mirror := filepath.Join(dir, "mirror")
+	require.NoError(t, os.MkdirAll(filepath.Join(mirror, "example.com", "docs"), 0750))
+	require.NoError(t, os.WriteFile(filepath.Join(mirror, "example.com", "index.html"), []byte("<html>home</html>"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(mirror, "example.com", "docs", "notes"), []byte("plain notes"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "secret.txt"), []byte("secret"), 0600))
+
+	registry := NewRegistry()
+	registry.Register(NewFileFetcher(mirror), "file")
+	registry.Register(NewDataFetcher(), "DATA")
+
+	fileURL := func(path string) string {
+		return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+	}
+
+	testCases := []struct {
+		name      string
+		url       string
+		mediaType string
+		charset   string
+		body      string
+	}{
+		{name: "directory index", url: fileURL(filepath.Join(mirror, "example.com")), mediaType: "text/html", charset: "utf-8", body: "<html>home</html>"},
+		{name: "sniffed file", url: fileURL(filepath.Join(mirror, "example.com", "docs", "notes")), mediaType: "text/plain", charset: "utf-8", body: "plain notes"},
+		{name: "data default", url: "data:,Hello%2C%20World!", mediaType: "text/plain", charset: "US-ASCII", body: "Hello, World!"},
+		{name: "data base64", url: "data:text/html;charset=utf-8;base64,PGI+aGk8L2I+", mediaType: "text/html", charset: "utf-8", body: "<b>hi</b>"},
+		{name: "data parameters only", url: "data:;charset=utf-8,caf%C3%A9", mediaType: "text/plain", charset: "utf-8", body: "café"},
+		{name: "data with query", url: "data:text/plain,a?b=c", mediaType: "text/plain", charset: "", body: "a?b=c"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resource, err := FetchTask(t.Context(), registry, &model.Task[struct{}]{Url: tc.url})
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.mediaType, resource.ContentType())
+			assert.Equal(t, tc.charset, resource.Charset)
+			assert.Equal(t, tc.body, string(resource.Body))
+		})
+	}
+
+	t.Run("outside of root", func(t *testing.T) {
+		_, err := FetchTask(t.Context(), registry, &model.Task[struct{}]{Url: fileURL(filepath.Join(mirror, "..", "secret.txt"))})
+		assert.ErrorIs(t, err, fs.ErrPermission)
+	})
+
+	t.Run("symlink outside of root", func(t *testing.T) {
+		link := filepath.Join(mirror, "link.txt")
+		require.NoError(t, os.Symlink(filepath.Join(dir, "secret.txt"), link))
+
+		_, err := FetchTask(t.Context(), registry, &model.Task[struct{}]{Url: fileURL(link)})
+		assert.Error(t, err)
+	})
+
+	t.Run("malformed data url", func(t *testing.T) {
+		_, err := FetchTask(t.Context(), registry, &model.Task[struct{}]{Url: "data:text/plain;base64"})
+		assert.ErrorIs(t, err, ErrMalformedDataURL)
+	})
+
+	t.Run("unsupported scheme", func(t *testing.T) {
+		_, err := FetchTask(t.Context(), registry, &model.Task[struct{}]{Url: "ftp://example.com/file"})
+		assert.ErrorIs(t, err, ErrUnsupportedScheme)
+	})
+}
@@ -0,0 +1,119 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package fetcher
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const indexFile = "index.html"
+
+// FileFetcher reads the local files referenced via `file://` URLs.
+// (eg: corpora on disk, local mirrors created by `wget --mirror`)
+//
+// The directories are served via their `index.html`, as laid out by the
+// mirroring tools.
+type FileFetcher struct {
+	root string
+}
+
+// Reads the files within the root directory only, the URLs pointing outside of
+// it (including via symlinks) are rejected. Use "/" to allow any file.
+func NewFileFetcher(root string) *FileFetcher {
+	return &FileFetcher{root: root}
+}
+
+func (f *FileFetcher) Fetch(ctx context.Context, u *url.URL) (*Resource, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if !strings.EqualFold(u.Scheme, "file") {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedScheme, u.Scheme)
+	}
+	if u.Host != "" && u.Host != "localhost" {
+		return nil, fmt.Errorf("file fetcher: remote host not supported: %q", u.Host)
+	}
+
+	name, err := f.relativePath(u.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := os.OpenRoot(f.root)
+	if err != nil {
+		return nil, fmt.Errorf("file fetcher: %w", err)
+	}
+	defer func() { _ = root.Close() }()
+
+	info, err := root.Stat(name)
+	if err != nil {
+		return nil, fmt.Errorf("file fetcher: %w", err)
+	}
+	if info.IsDir() {
+		name = filepath.Join(name, indexFile)
+	}
+
+	file, err := root.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("file fetcher: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	body, err := io.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("file fetcher: %w", err)
+	}
+
+	mediaType, charset := fileMediaType(name, body)
+
+	return &Resource{
+		FetchedAt: time.Now(),
+		URL:       u,
+		Source:    info,
+		MediaType: mediaType,
+		Charset:   charset,
+		Body:      body,
+	}, nil
+}
+
+// Path of the file relative to the root.
+func (f *FileFetcher) relativePath(path string) (string, error) {
+	root, err := filepath.Abs(f.root)
+	if err != nil {
+		return "", fmt.Errorf("file fetcher: %w", err)
+	}
+
+	rel, err := filepath.Rel(root, filepath.Clean(filepath.FromSlash(path)))
+	if err != nil || !(rel == "." || filepath.IsLocal(rel)) {
+		return "", fmt.Errorf("file fetcher: %w: %q is outside of the root", fs.ErrPermission, path)
+	}
+	return rel, nil
+}
+
+// Determines the media type via the file extension, falls back to sniffing the content.
+func fileMediaType(name string, body []byte) (string, string) {
+	contentType := mime.TypeByExtension(filepath.Ext(name))
+	if contentType == "" {
+		contentType = http.DetectContentType(body)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "application/octet-stream", ""
+	}
+	return mediaType, params["charset"]
+}
+
+var _ Fetcher = (*FileFetcher)(nil)
@@ -0,0 +1,302 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultExpiryDelta = 10 * time.Second
+	maxTokenResponse   = 1 << 20
+)
+
+// Sets the credentials on the outgoing requests of [HttpFetcher].
+//
+// Implementations must be safe for concurrent use.
+type Authenticator interface {
+	// Sets the credentials (eg: `Authorization` header) on the request,
+	// acquiring them first if needed.
+	Authenticate(req *http.Request) error
+
+	// Called when the server rejected the credentials of the request with
+	// `401 Unauthorized`. Returns true if the credentials were renewed and the
+	// request should be retried (once).
+	Refresh(req *http.Request) (bool, error)
+}
+
+type staticBearer struct {
+	token string
+}
+
+// Sets the fixed bearer token, it's never refreshed.
+func NewStaticBearer(token string) Authenticator {
+	return &staticBearer{token: token}
+}
+
+func (a *staticBearer) Authenticate(req *http.Request) error {
+	setBearer(req, a.token)
+	return nil
+}
+
+func (a *staticBearer) Refresh(*http.Request) (bool, error) {
+	return false, nil
+}
+
+// Returned when the token endpoint rejected the request (RFC 6749, section 5.2).
+type TokenError struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description"`
+	StatusCode  int    `json:"-"`
+}
+
+func (e *TokenError) Error() string {
+	msg := fmt.Sprintf("oauth2: token request failed with status %d", e.StatusCode)
+	if e.Code != "" {
+		msg += ": " + e.Code
+	}
+	if e.Description != "" {
+		msg += " (" + e.Description + ")"
+	}
+	return msg
+}
+
+type OAuth2Config struct {
+	// Used for the token requests, defaults to [http.DefaultClient]
+	Client HttpClient
+
+	// Token endpoint
+	TokenURL string
+
+	ClientID     string
+	ClientSecret string
+
+	// Initial refresh token, required for the refresh-token flow.
+	// It's replaced when the server issues a new one (rotation).
+	RefreshToken string
+
+	Scopes []string
+
+	// Tokens are renewed this long before they expire, defaults to 10s
+	ExpiryDelta time.Duration
+
+	// Send the client credentials as form parameters instead of
+	// HTTP basic authentication. (eg: for non-compliant servers)
+	CredentialsInBody bool
+}
+
+// OAuth2 is an [Authenticator] acquiring bearer tokens from the token
+// endpoint via client-credentials or refresh-token grant (RFC 6749).
+//
+// Tokens are cached until they (are about to) expire or are rejected by the
+// server, concurrent requests share a single token request.
+type OAuth2 struct {
+	grantType    string
+	accessToken  string
+	refreshToken string
+	expiry       time.Time
+	config       OAuth2Config
+	mu           sync.Mutex
+}
+
+// Authenticator using the client-credentials grant, for machine-to-machine APIs.
+func NewClientCredentials(config OAuth2Config) (*OAuth2, error) {
+	return newOAuth2(config, "client_credentials")
+}
+
+// Authenticator using the refresh-token grant, starting from [OAuth2Config.RefreshToken].
+func NewRefreshToken(config OAuth2Config) (*OAuth2, error) {
+	if config.RefreshToken == "" {
+		return nil, errors.New("oauth2: refresh token is required")
+	}
+	return newOAuth2(config, "refresh_token")
+}
+
+func newOAuth2(config OAuth2Config, grantType string) (*OAuth2, error) {
+	if config.TokenURL == "" {
+		return nil, errors.New("oauth2: token url is required")
+	}
+	if _, err := url.Parse(config.TokenURL); err != nil {
+		return nil, fmt.Errorf("oauth2: invalid token url: %w", err)
+	}
+	if config.Client == nil {
+		config.Client = http.DefaultClient
+	}
+	if config.ExpiryDelta <= 0 {
+		config.ExpiryDelta = defaultExpiryDelta
+	}
+
+	return &OAuth2{
+		config:       config,
+		grantType:    grantType,
+		refreshToken: config.RefreshToken,
+	}, nil
+}
+
+func (a *OAuth2) Authenticate(req *http.Request) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if !a.valid(time.Now()) {
+		if err := a.fetchToken(req); err != nil {
+			return err
+		}
+	}
+
+	setBearer(req, a.accessToken)
+	return nil
+}
+
+func (a *OAuth2) Refresh(req *http.Request) (bool, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// Already renewed by a concurrent request
+	if req.Header.Get(HeaderAuthorization) != "Bearer "+a.accessToken && a.valid(time.Now()) {
+		return true, nil
+	}
+
+	if err := a.fetchToken(req); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Current access token, if any.
+func (a *OAuth2) Token() (string, time.Time) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.accessToken, a.expiry
+}
+
+func (a *OAuth2) valid(now time.Time) bool {
+	if a.accessToken == "" {
+		return false
+	}
+	return a.expiry.IsZero() || now.Add(a.config.ExpiryDelta).Before(a.expiry)
+}
+
+// Requests a new token, must be called with the lock held.
+func (a *OAuth2) fetchToken(origin *http.Request) error {
+	form := url.Values{"grant_type": {a.grantType}}
+	if a.grantType == "refresh_token" {
+		form.Set("refresh_token", a.refreshToken)
+	}
+	if len(a.config.Scopes) > 0 {
+		form.Set("scope", strings.Join(a.config.Scopes, " "))
+	}
+	if a.config.CredentialsInBody {
+		form.Set("client_id", a.config.ClientID)
+		if a.config.ClientSecret != "" {
+			form.Set("client_secret", a.config.ClientSecret)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(origin.Context(), http.MethodPost, a.config.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("oauth2: %w", err)
+	}
+	req.Header.Set(HeaderContentType, "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if !a.config.CredentialsInBody {
+		req.SetBasicAuth(url.QueryEscape(a.config.ClientID), url.QueryEscape(a.config.ClientSecret))
+	}
+
+	resp, err := a.config.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("oauth2: token request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxTokenResponse))
+	if err != nil {
+		return fmt.Errorf("oauth2: failed to read token response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		tokenErr := &TokenError{StatusCode: resp.StatusCode}
+		_ = json.Unmarshal(body, tokenErr)
+		return tokenErr
+	}
+
+	var token struct {
+		AccessToken  string `json:"access_token"`
+		TokenType    string `json:"token_type"`
+		RefreshToken string `json:"refresh_token"`
+		ExpiresIn    int64  `json:"expires_in"`
+	}
+	if err := json.Unmarshal(body, &token); err != nil {
+		return fmt.Errorf("oauth2: invalid token response: %w", err)
+	}
+	if token.AccessToken == "" {
+		return errors.New("oauth2: token response without access_token")
+	}
+	if token.TokenType != "" && !strings.EqualFold(token.TokenType, "bearer") {
+		return fmt.Errorf("oauth2: unsupported token type: %q", token.TokenType)
+	}
+
+	a.accessToken = token.AccessToken
+	a.expiry = time.Time{}
+	if token.ExpiresIn > 0 {
+		a.expiry = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
+	if token.RefreshToken != "" {
+		a.refreshToken = token.RefreshToken
+	}
+
+	return nil
+}
+
+// Maps the hosts to their authenticators, so that the credentials are only
+// sent to the hosts they belong to (including across redirects).
+//
+// The hosts are matched without port, and the entries with a leading dot
+// (eg: ".example.com") match the domain and all of its subdomains. The
+// requests to the unmatched hosts are sent without credentials.
+type HostAuthenticator map[string]Authenticator
+
+func (h HostAuthenticator) Authenticate(req *http.Request) error {
+	if auth := h.lookup(req.URL); auth != nil {
+		return auth.Authenticate(req)
+	}
+	return nil
+}
+
+func (h HostAuthenticator) Refresh(req *http.Request) (bool, error) {
+	if auth := h.lookup(req.URL); auth != nil {
+		return auth.Refresh(req)
+	}
+	return false, nil
+}
+
+func (h HostAuthenticator) lookup(u *url.URL) Authenticator {
+	host := strings.ToLower(u.Hostname())
+	if auth, ok := h[host]; ok {
+		return auth
+	}
+
+	for domain := host; domain != ""; {
+		if auth, ok := h["."+domain]; ok {
+			return auth
+		}
+		_, parent, found := strings.Cut(domain, ".")
+		if !found {
+			break
+		}
+		domain = parent
+	}
+	return nil
+}
+
+func setBearer(req *http.Request, token string) {
+	req.Header.Set(HeaderAuthorization, "Bearer "+token)
+}
@@ -0,0 +1,226 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Stand-in for the authorization server and the protected API.
+type tokenServer struct {
+	refreshTokens map[string]bool
+	valid         string
+	grants        []string
+	issued        int
+	mu            sync.Mutex
+}
+
+func newTokenServer(t *testing.T) (*tokenServer, *httptest.Server) {
+	ts := &tokenServer{refreshTokens: map[string]bool{"refresh-0": true}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /token", func(w http.ResponseWriter, r *http.Request) {
+		ts.mu.Lock()
+		defer ts.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		grant := r.PostFormValue("grant_type")
+		ts.grants = append(ts.grants, grant)
+
+		switch grant {
+		case "client_credentials":
+			if id, secret, ok := r.BasicAuth(); !ok || id != "crawler" || secret != "s3cret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"error":"invalid_client"}`))
+				return
+			}
+		case "refresh_token":
+			if token := r.PostFormValue("refresh_token"); !ts.refreshTokens[token] {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+				return
+			}
+		}
+
+		ts.issued++
+		ts.valid = fmt.Sprintf("access-%d", ts.issued)
+		refresh := fmt.Sprintf("refresh-%d", ts.issued)
+		ts.refreshTokens[refresh] = true
+
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token":  ts.valid,
+			"token_type":    "Bearer",
+			"expires_in":    3600,
+			"refresh_token": refresh,
+		})
+	})
+	mux.HandleFunc("/api", func(w http.ResponseWriter, r *http.Request) {
+		ts.mu.Lock()
+		defer ts.mu.Unlock()
+
+		if r.Header.Get("Authorization") != "Bearer "+ts.valid {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte("ok"))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return ts, server
+}
+
+// Revokes the issued access token.
+func (ts *tokenServer) revoke() {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	ts.valid = "revoked"
+}
+
+func TestAuthenticator(t *testing.T) {
+	status := func(t *testing.T, f *HttpFetcher, url string) int {
+		t.Helper()
+		resp, err := f.Get(t.Context(), url)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		return resp.StatusCode
+	}
+
+	t.Run("client credentials", func(t *testing.T) {
+		ts, server := newTokenServer(t)
+
+		auth, err := NewClientCredentials(OAuth2Config{
+			Client:       server.Client(),
+			TokenURL:     server.URL + "/token",
+			ClientID:     "crawler",
+			ClientSecret: "s3cret",
+		})
+		require.NoError(t, err)
+
+		f, err := NewHttpFetcher(server.Client(), WithAuthenticator(auth))
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, status(t, f, server.URL+"/api"))
+		assert.Equal(t, http.StatusOK, status(t, f, server.URL+"/api"))
+		assert.Equal(t, 1, ts.issued, "token must be cached")
+
+		// Refreshed on 401 and retried
+		ts.revoke()
+		assert.Equal(t, http.StatusOK, status(t, f, server.URL+"/api"))
+		assert.Equal(t, 2, ts.issued)
+
+		token, _ := auth.Token()
+		assert.Equal(t, "access-2", token)
+	})
+
+	t.Run("invalid client", func(t *testing.T) {
+		_, server := newTokenServer(t)
+
+		auth, err := NewClientCredentials(OAuth2Config{
+			Client:   server.Client(),
+			TokenURL: server.URL + "/token",
+			ClientID: "crawler",
+		})
+		require.NoError(t, err)
+
+		f, err := NewHttpFetcher(server.Client(), WithAuthenticator(auth))
+		require.NoError(t, err)
+
+		_, err = f.Get(t.Context(), server.URL+"/api")
+		var tokenErr *TokenError
+		require.ErrorAs(t, err, &tokenErr)
+		assert.Equal(t, "invalid_client", tokenErr.Code)
+	})
+
+	t.Run("refresh token rotation", func(t *testing.T) {
+		ts, server := newTokenServer(t)
+
+		auth, err := NewRefreshToken(OAuth2Config{
+			Client:       server.Client(),
+			TokenURL:     server.URL + "/token",
+			ClientID:     "crawler",
+			ClientSecret: "s3cret",
+			RefreshToken: "refresh-0",
+		})
+		require.NoError(t, err)
+
+		f, err := NewHttpFetcher(server.Client(), WithAuthenticator(auth))
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, status(t, f, server.URL+"/api"))
+
+		ts.revoke()
+		delete(ts.refreshTokens, "refresh-0")
+		assert.Equal(t, http.StatusOK, status(t, f, server.URL+"/api"))
+		assert.Equal(t, []string{"refresh_token", "refresh_token"}, ts.grants)
+	})
+
+	t.Run("static bearer is not retried", func(t *testing.T) {
+		ts, server := newTokenServer(t)
+		ts.revoke()
+
+		f, err := NewHttpFetcher(server.Client(), WithAuthenticator(NewStaticBearer("stale")))
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusUnauthorized, status(t, f, server.URL+"/api"))
+	})
+
+	t.Run("per host", func(t *testing.T) {
+		var received []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received = append(received, r.Header.Get("Authorization"))
+		}))
+		t.Cleanup(server.Close)
+
+		f, err := NewHttpFetcher(server.Client(), WithAuthenticator(HostAuthenticator{
+			"127.0.0.1":    NewStaticBearer("local"),
+			".example.com": NewStaticBearer("example"),
+		}))
+		require.NoError(t, err)
+
+		status(t, f, server.URL)
+		status(t, f, "http://localhost:"+server.URL[len("http://127.0.0.1:"):])
+		assert.Equal(t, []string{"Bearer local", ""}, received)
+
+		auth := HostAuthenticator{".example.com": NewStaticBearer("example")}
+		for host, want := range map[string]bool{"example.com": true, "api.example.com": true, "notexample.com": false} {
+			req, err := http.NewRequest(http.MethodGet, "https://"+host, nil)
+			require.NoError(t, err)
+			require.NoError(t, auth.Authenticate(req))
+			assert.Equal(t, want, req.Header.Get("Authorization") != "", host)
+		}
+	})
+
+	t.Run("cross-host redirect", func(t *testing.T) {
+		var received []string
+		target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received = append(received, r.Header.Get("Authorization"))
+		}))
+		t.Cleanup(target.Close)
+
+		origin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received = append(received, r.Header.Get("Authorization"))
+			http.Redirect(w, r, "http://localhost:"+target.URL[len("http://127.0.0.1:"):], http.StatusFound)
+		}))
+		t.Cleanup(origin.Close)
+
+		f, err := NewHttpFetcher(newNoRedirectClient(),
+			WithAuthenticator(NewStaticBearer("secret")),
+			WithRedirectPolicy(RedirectPolicy{}),
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, status(t, f, origin.URL))
+		assert.Equal(t, []string{"Bearer secret", ""}, received, "the token must not follow the redirect")
+	})
+}
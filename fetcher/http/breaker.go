@@ -0,0 +1,292 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var ErrCircuitOpen = errors.New("circuit breaker: circuit open")
+
+// CircuitOpenError is returned without making the request, while the circuit
+// for the host is open. The frontier can defer the host's tasks until `RetryAt`.
+type CircuitOpenError struct {
+	RetryAt time.Time
+	Host    string
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("circuit breaker: circuit open for host %s, retry at %s", e.Host, e.RetryAt.Format(time.RFC3339))
+}
+
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+type CircuitState int
+
+const (
+	// Requests flow normally, outcomes are tracked
+	CircuitClosed CircuitState = iota
+
+	// Requests fail fast with [CircuitOpenError]
+	CircuitOpen
+
+	// Limited trial requests decide whether to close or re-open the circuit
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+type CircuitBreakerConfig struct {
+	// Classifies the outcome of an exchange as failure, defaults to transport
+	// errors and 5xx responses. Context cancellation is never a failure.
+	IsFailure func(resp *http.Response, err error) bool
+
+	// Failure rate (0, 1] within the window that opens the circuit, defaults to 0.5.
+	FailureRate float64
+
+	// Duration the circuit stays open before trial requests, defaults to 30 seconds.
+	OpenTimeout time.Duration
+
+	// Number of most recent outcomes considered per host, defaults to 20.
+	WindowSize int
+
+	// Minimum outcomes within the window before the failure rate is evaluated, defaults to 5.
+	MinRequests int
+
+	// Concurrent trial requests allowed in half-open state, all of them must
+	// succeed to close the circuit, defaults to 1.
+	HalfOpenRequests int
+}
+
+func (c *CircuitBreakerConfig) validate() error {
+	if c.FailureRate < 0 || c.FailureRate > 1 {
+		return errors.New("circuit breaker: failure rate must be within (0, 1]")
+	}
+	if c.OpenTimeout < 0 || c.WindowSize < 0 || c.MinRequests < 0 || c.HalfOpenRequests < 0 {
+		return errors.New("circuit breaker: durations and counts cannot be negative")
+	}
+	if c.IsFailure == nil {
+		c.IsFailure = func(resp *http.Response, err error) bool {
+			return err != nil || resp.StatusCode >= http.StatusInternalServerError
+		}
+	}
+	if c.FailureRate == 0 {
+		c.FailureRate = 0.5
+	}
+	if c.OpenTimeout == 0 {
+		c.OpenTimeout = 30 * time.Second
+	}
+	if c.WindowSize == 0 {
+		c.WindowSize = 20
+	}
+	if c.MinRequests == 0 {
+		c.MinRequests = 5
+	}
+	if c.MinRequests > c.WindowSize {
+		return errors.New("circuit breaker: min requests cannot exceed the window size")
+	}
+	if c.HalfOpenRequests == 0 {
+		c.HalfOpenRequests = 1
+	}
+	return nil
+}
+
+type circuit struct {
+	openedAt time.Time
+	// Ring buffer of the recent outcomes (true: failure)
+	outcomes []bool
+	state    CircuitState
+	next     int
+	count    int
+	failures int
+	// Trial requests in-flight and succeeded, in half-open state
+	trials    int
+	successes int
+}
+
+func (c *circuit) observe(failed bool) {
+	if c.count == len(c.outcomes) {
+		if c.outcomes[c.next] {
+			c.failures--
+		}
+	} else {
+		c.count++
+	}
+
+	c.outcomes[c.next] = failed
+	if failed {
+		c.failures++
+	}
+	c.next = (c.next + 1) % len(c.outcomes)
+}
+
+func (c *circuit) reset(state CircuitState) {
+	clear(c.outcomes)
+	c.state = state
+	c.next, c.count, c.failures = 0, 0, 0
+	c.trials, c.successes = 0, 0
+}
+
+// Transition of the circuit state, reported via [EventHooks].
+type circuitTransition struct {
+	host     string
+	from, to CircuitState
+}
+
+// CircuitBreaker tracks the outcome of the requests per host, and rejects the
+// requests to hosts that are failing, instead of letting each of them time out.
+//
+// The circuits are tracked from the first failure, and evicted once closed
+// without any failures within the window, so that the breaker doesn't grow
+// with every host ever requested.
+type CircuitBreaker struct {
+	circuits map[string]*circuit
+	config   CircuitBreakerConfig
+	mu       sync.Mutex
+}
+
+func NewCircuitBreaker(config CircuitBreakerConfig) (*CircuitBreaker, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	return &CircuitBreaker{
+		circuits: make(map[string]*circuit),
+		config:   config,
+	}, nil
+}
+
+// Current state of the circuit for the host.
+func (b *CircuitBreaker) State(host string) CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c, ok := b.circuits[host]
+	if !ok {
+		return CircuitClosed
+	}
+	if c.state == CircuitOpen && time.Since(c.openedAt) >= b.config.OpenTimeout {
+		return CircuitHalfOpen
+	}
+	return c.state
+}
+
+// Admits a request to the host, or returns [CircuitOpenError].
+func (b *CircuitBreaker) allow(host string) (*circuitTransition, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c, ok := b.circuits[host]
+	if !ok {
+		return nil, nil
+	}
+
+	var transition *circuitTransition
+	if c.state == CircuitOpen {
+		retryAt := c.openedAt.Add(b.config.OpenTimeout)
+		if time.Now().Before(retryAt) {
+			return nil, &CircuitOpenError{Host: host, RetryAt: retryAt}
+		}
+		c.reset(CircuitHalfOpen)
+		transition = &circuitTransition{host: host, from: CircuitOpen, to: CircuitHalfOpen}
+	}
+
+	if c.state == CircuitHalfOpen {
+		if c.trials >= b.config.HalfOpenRequests {
+			return transition, &CircuitOpenError{Host: host, RetryAt: time.Now().Add(b.config.OpenTimeout)}
+		}
+		c.trials++
+	}
+
+	return transition, nil
+}
+
+// Records the outcome of an admitted request.
+func (b *CircuitBreaker) record(host string, resp *http.Response, err error) *circuitTransition {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	canceled := errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+	failed := !canceled && b.config.IsFailure(resp, err)
+
+	c, ok := b.circuits[host]
+	if !ok {
+		if !failed {
+			return nil
+		}
+		c = b.circuit(host)
+	}
+
+	switch c.state {
+	case CircuitHalfOpen:
+		switch {
+		case canceled:
+			c.trials--
+		case failed:
+			c.reset(CircuitOpen)
+			c.openedAt = time.Now()
+			return &circuitTransition{host: host, from: CircuitHalfOpen, to: CircuitOpen}
+		default:
+			c.successes++
+			if c.successes >= b.config.HalfOpenRequests {
+				delete(b.circuits, host)
+				return &circuitTransition{host: host, from: CircuitHalfOpen, to: CircuitClosed}
+			}
+		}
+
+	case CircuitClosed:
+		if canceled {
+			return nil
+		}
+		c.observe(failed)
+		if c.count >= b.config.MinRequests && float64(c.failures)/float64(c.count) >= b.config.FailureRate {
+			c.reset(CircuitOpen)
+			c.openedAt = time.Now()
+			return &circuitTransition{host: host, from: CircuitClosed, to: CircuitOpen}
+		}
+		if c.failures == 0 {
+			delete(b.circuits, host)
+		}
+	}
+
+	return nil
+}
+
+// Releases an admitted request that was never made (i.e. without an outcome),
+// freeing its trial slot in half-open state.
+func (b *CircuitBreaker) release(host string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if c, ok := b.circuits[host]; ok && c.state == CircuitHalfOpen && c.trials > 0 {
+		c.trials--
+	}
+}
+
+func (b *CircuitBreaker) circuit(host string) *circuit {
+	c, ok := b.circuits[host]
+	if !ok {
+		c = &circuit{outcomes: make([]bool, b.config.WindowSize)}
+		b.circuits[host] = c
+	}
+	return c
+}
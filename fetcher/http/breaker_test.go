@@ -0,0 +1,165 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	var healthy atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	t.Cleanup(server.Close)
+
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	host := u.Host
+
+	breaker, err := NewCircuitBreaker(CircuitBreakerConfig{
+		WindowSize:  4,
+		MinRequests: 2,
+		OpenTimeout: 50 * time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	var transitions []CircuitState
+	hooks := NoopEventHook
+	hooks.OnCircuitStateChange = func(_ string, _, to CircuitState) {
+		transitions = append(transitions, to)
+	}
+
+	f, err := NewHttpFetcher(server.Client(), WithCircuitBreaker(breaker), WithEventHooks(hooks))
+	require.NoError(t, err)
+
+	get := func() error {
+		resp, err := f.Get(t.Context(), server.URL)
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	}
+
+	require.NoError(t, get())
+	require.NoError(t, get())
+	assert.Equal(t, CircuitOpen, breaker.State(host))
+
+	err = get()
+	var openErr *CircuitOpenError
+	require.ErrorAs(t, err, &openErr, "requests must fail fast while open")
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, host, openErr.Host)
+
+	time.Sleep(60 * time.Millisecond)
+	assert.Equal(t, CircuitHalfOpen, breaker.State(host))
+
+	// Failed trial re-opens the circuit
+	require.NoError(t, get())
+	assert.Equal(t, CircuitOpen, breaker.State(host))
+
+	time.Sleep(60 * time.Millisecond)
+	healthy.Store(true)
+	require.NoError(t, get())
+	assert.Equal(t, CircuitClosed, breaker.State(host))
+
+	assert.Equal(t, []CircuitState{CircuitOpen, CircuitHalfOpen, CircuitOpen, CircuitHalfOpen, CircuitClosed}, transitions)
+	assert.Empty(t, breaker.circuits, "closed after the trial")
+}
+
+func TestCircuitBreakerEviction(t *testing.T) {
+	breaker, err := NewCircuitBreaker(CircuitBreakerConfig{WindowSize: 3, MinRequests: 3})
+	require.NoError(t, err)
+
+	ok := &http.Response{StatusCode: http.StatusOK}
+	exchange := func(host string, resp *http.Response, err error) {
+		_, allowErr := breaker.allow(host)
+		require.NoError(t, allowErr)
+		breaker.record(host, resp, err)
+	}
+
+	for i := range 100 {
+		exchange("host-"+strconv.Itoa(i)+".test", ok, nil)
+	}
+	assert.Empty(t, breaker.circuits, "hosts without failures aren't tracked")
+
+	exchange("flaky.test", nil, errors.New("connection reset"))
+	exchange("flaky.test", ok, nil)
+	exchange("flaky.test", ok, nil)
+	assert.Len(t, breaker.circuits, 1, "the failure is within the window")
+	assert.Equal(t, CircuitClosed, breaker.State("flaky.test"))
+
+	exchange("flaky.test", ok, nil)
+	assert.Empty(t, breaker.circuits, "the failure slid out of the window")
+}
+
+func TestCircuitBreakerProxyUnavailable(t *testing.T) {
+	var healthy atomic.Bool
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	t.Cleanup(proxy.Close)
+
+	proxyURL, err := url.Parse(proxy.URL)
+	require.NoError(t, err)
+
+	pool, err := NewProxyPool(ProxyPoolConfig{
+		Proxies:     []string{proxy.URL},
+		MaxFailures: 1,
+		Cooldown:    time.Hour,
+	})
+	require.NoError(t, err)
+
+	breaker, err := NewCircuitBreaker(CircuitBreakerConfig{
+		WindowSize:  1,
+		MinRequests: 1,
+		OpenTimeout: 50 * time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	client := &http.Client{Transport: &http.Transport{Proxy: pool.Proxy}}
+	f, err := NewHttpFetcher(client, WithCircuitBreaker(breaker), WithProxyPool(pool))
+	require.NoError(t, err)
+
+	get := func() error {
+		resp, err := f.Get(t.Context(), "http://origin.test/")
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	}
+
+	require.NoError(t, get())
+	require.Equal(t, CircuitOpen, breaker.State("origin.test"))
+
+	time.Sleep(60 * time.Millisecond)
+	pool.ReportFailure(proxyURL)
+
+	// Admitted as the half-open trial, but never made
+	assert.ErrorIs(t, get(), ErrNoHealthyProxy)
+	assert.Equal(t, CircuitHalfOpen, breaker.State("origin.test"))
+
+	pool.ReportSuccess(proxyURL)
+	healthy.Store(true)
+	require.NoError(t, get(), "the trial slot must be released")
+	assert.Equal(t, CircuitClosed, breaker.State("origin.test"))
+}
@@ -0,0 +1,333 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+
+	fetcher "github.com/ritvikos/synapse/fetcher/http"
+)
+
+var ErrInteractionNotFound = errors.New("cassette: no recorded interaction matches the request")
+
+// Value recorded in place of the redacted headers.
+const Redacted = "REDACTED"
+
+// Request headers redacted by default.
+var DefaultRedactHeaders = []string{"Authorization", "Cookie", "Proxy-Authorization"}
+
+// Response headers redacted by default.
+var DefaultRedactResponseHeaders = []string{"Set-Cookie"}
+
+type Mode int
+
+const (
+	// Serve the recorded interactions, without touching the network.
+	ModeReplay Mode = iota
+
+	// Forward the requests to the underlying transport and record the interactions.
+	ModeRecord
+)
+
+type Config struct {
+	// Underlying transport used in record mode, defaults to [http.DefaultTransport].
+	Transport http.RoundTripper
+
+	// Path of the cassette file
+	Path string
+
+	// Request headers that must match (besides method and URL) while replaying.
+	MatchHeaders []string
+
+	// Request headers recorded as [Redacted], so that the credentials don't
+	// end up in the cassette files, defaults to [DefaultRedactHeaders].
+	// The redacted headers within MatchHeaders match any value while replaying.
+	RedactHeaders []string
+
+	// Response headers recorded as [Redacted] (eg: the session cookies),
+	// defaults to [DefaultRedactResponseHeaders]. The responses served while
+	// recording carry the original values.
+	RedactResponseHeaders []string
+
+	Mode Mode
+}
+
+func (c Config) validate() error {
+	if c.Path == "" {
+		return errors.New("cassette: path cannot be empty")
+	}
+	if c.Mode != ModeReplay && c.Mode != ModeRecord {
+		return fmt.Errorf("cassette: unknown mode %d", c.Mode)
+	}
+	return nil
+}
+
+type Request struct {
+	Header http.Header `json:"header,omitempty"`
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+}
+
+type Response struct {
+	Header     http.Header `json:"header,omitempty"`
+	Status     string      `json:"status"`
+	Proto      string      `json:"proto"`
+	Body       []byte      `json:"body,omitempty"`
+	StatusCode int         `json:"status_code"`
+}
+
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Cassette records HTTP exchanges into a file and serves them back, so that
+// the components depending on the network can be tested hermetically.
+//
+// It implements both the [fetcher.HttpClient] and [http.RoundTripper] interfaces,
+// so it can be passed directly to the [fetcher.HttpFetcher], or as the transport
+// of an [http.Client] (eg: for [robots.DefaultRobotsFetcher]).
+//
+// The response bodies are recorded as received from the underlying transport,
+// so the fetcher's decompression and charset normalization apply while replaying.
+//
+// While replaying, requests are matched by method, URL and configured headers.
+// Repeated requests are served in recorded order, the last matching interaction
+// is served once the others are exhausted.
+//
+// # Example Usage
+//
+//	c, err := cassette.New(cassette.Config{
+//		Path: "testdata/example.json",
+//		Mode: cassette.ModeRecord,
+//	})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer c.Save()
+//
+//	f, err := http.NewHttpFetcher(c)
+type Cassette struct {
+	interactions []*Interaction
+	replayed     map[*Interaction]bool
+	config       Config
+	mu           sync.Mutex
+}
+
+// Creates a new cassette, the recorded interactions are loaded in replay mode.
+func New(config Config) (*Cassette, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	if config.Transport == nil {
+		config.Transport = http.DefaultTransport
+	}
+	if config.RedactHeaders == nil {
+		config.RedactHeaders = DefaultRedactHeaders
+	}
+	if config.RedactResponseHeaders == nil {
+		config.RedactResponseHeaders = DefaultRedactResponseHeaders
+	}
+
+	c := &Cassette{
+		replayed: make(map[*Interaction]bool),
+		config:   config,
+	}
+
+	if config.Mode == ModeReplay {
+		data, err := os.ReadFile(config.Path)
+		if err != nil {
+			return nil, fmt.Errorf("cassette: failed to read cassette: %w", err)
+		}
+		if err := json.Unmarshal(data, &c.interactions); err != nil {
+			return nil, fmt.Errorf("cassette: failed to decode cassette: %w", err)
+		}
+	}
+
+	return c, nil
+}
+
+func (c *Cassette) Do(req *http.Request) (*http.Response, error) {
+	if c.config.Mode == ModeRecord {
+		return c.record(req)
+	}
+	return c.replay(req)
+}
+
+func (c *Cassette) RoundTrip(req *http.Request) (*http.Response, error) {
+	return c.Do(req)
+}
+
+// Recorded interactions so far
+func (c *Cassette) Interactions() []Interaction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	interactions := make([]Interaction, 0, len(c.interactions))
+	for _, interaction := range c.interactions {
+		interactions = append(interactions, *interaction)
+	}
+	return interactions
+}
+
+// Atomically writes the recorded interactions to the cassette file.
+// It's a no-op in replay mode.
+func (c *Cassette) Save() error {
+	if c.config.Mode != ModeRecord {
+		return nil
+	}
+
+	c.mu.Lock()
+	data, err := json.MarshalIndent(c.interactions, "", "  ")
+	c.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("cassette: failed to encode cassette: %w", err)
+	}
+
+	dir := filepath.Dir(c.config.Path)
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return fmt.Errorf("cassette: failed to create directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, "*.tmp")
+	if err != nil {
+		return fmt.Errorf("cassette: failed to create temporary file: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("cassette: failed to write cassette: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("cassette: failed to write cassette: %w", err)
+	}
+
+	return os.Rename(tmp.Name(), c.config.Path)
+}
+
+func (c *Cassette) record(req *http.Request) (*http.Response, error) {
+	resp, err := c.config.Transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if closeErr := resp.Body.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cassette: failed to read response body: %w", err)
+	}
+
+	interaction := &Interaction{
+		Request: Request{
+			Header: redact(req.Header, c.config.RedactHeaders),
+			Method: req.Method,
+			URL:    req.URL.String(),
+		},
+		Response: Response{
+			Header:     redact(resp.Header, c.config.RedactResponseHeaders),
+			Status:     resp.Status,
+			Proto:      resp.Proto,
+			Body:       body,
+			StatusCode: resp.StatusCode,
+		},
+	}
+
+	c.mu.Lock()
+	c.interactions = append(c.interactions, interaction)
+	c.mu.Unlock()
+
+	live := interaction.Response
+	live.Header = resp.Header
+	return live.toHTTP(req), nil
+}
+
+func (c *Cassette) replay(req *http.Request) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var last *Interaction
+	for _, interaction := range c.interactions {
+		if !c.matches(interaction, req) {
+			continue
+		}
+		if !c.replayed[interaction] {
+			c.replayed[interaction] = true
+			return interaction.Response.toHTTP(req), nil
+		}
+		last = interaction
+	}
+
+	if last != nil {
+		return last.Response.toHTTP(req), nil
+	}
+
+	return nil, fmt.Errorf("%w: %s %s", ErrInteractionNotFound, req.Method, req.URL)
+}
+
+func (c *Cassette) matches(interaction *Interaction, req *http.Request) bool {
+	if interaction.Request.Method != req.Method || interaction.Request.URL != req.URL.String() {
+		return false
+	}
+
+	header := redact(req.Header, c.config.RedactHeaders)
+	for _, name := range c.config.MatchHeaders {
+		if interaction.Request.Header.Get(name) != header.Get(name) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Copy of the header with the values of the named headers replaced.
+func redact(header http.Header, names []string) http.Header {
+	redacted := header.Clone()
+	for _, name := range names {
+		if values := redacted.Values(name); len(values) > 0 {
+			redacted[http.CanonicalHeaderKey(name)] = []string{Redacted}
+		}
+	}
+	return redacted
+}
+
+func (r *Response) toHTTP(req *http.Request) *http.Response {
+	proto := r.Proto
+	if proto == "" {
+		proto = "HTTP/1.1"
+	}
+
+	resp := &http.Response{
+		Status:        r.Status,
+		StatusCode:    r.StatusCode,
+		Proto:         proto,
+		Header:        r.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+	resp.ProtoMajor, resp.ProtoMinor, _ = http.ParseHTTPVersion(proto)
+
+	if resp.Header == nil {
+		resp.Header = make(http.Header)
+	}
+
+	return resp
+}
+
+var (
+	_ fetcher.HttpClient = (*Cassette)(nil)
+	_ http.RoundTripper  = (*Cassette)(nil)
+)
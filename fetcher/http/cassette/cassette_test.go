@@ -0,0 +1,182 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package cassette
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	fetcher "github.com/ritvikos/synapse/fetcher/http"
+	"github.com/ritvikos/synapse/frontier/robots"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mapCache struct {
+	entries map[string]*robots.RobotsEntry
+	mu      sync.Mutex
+}
+
+func (m *mapCache) Set(_ context.Context, key string, value *robots.RobotsEntry, _ time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries[key] = value
+	return nil
+}
+
+func (m *mapCache) Get(_ context.Context, key string) (*robots.RobotsEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if entry, ok := m.entries[key]; ok {
+		return entry, nil
+	}
+	return nil, errors.New("not found")
+}
+
+func (m *mapCache) Purge(_ context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	clear(m.entries)
+	return nil
+}
+
+func fetchBody(t *testing.T, f *fetcher.HttpFetcher, url string, opts ...fetcher.RequestOptions) string {
+	t.Helper()
+
+	resp, err := f.Get(t.Context(), url, opts...)
+	require.NoError(t, err, "failed to fetch %s", url)
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err, "failed to read body")
+	return string(body)
+}
+
+func TestRecordReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/robots.txt":
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = io.WriteString(w, "User-agent: *\nDisallow: /private\n")
+		default:
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			_, _ = io.WriteString(w, "<html>"+r.URL.Path+" "+r.Header.Get("Accept-Language")+"</html>")
+		}
+	}))
+	origin := server.URL
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	matchHeaders := []string{"Accept-Language"}
+
+	recorder, err := New(Config{Path: path, Mode: ModeRecord, MatchHeaders: matchHeaders})
+	require.NoError(t, err, "failed to create recording cassette")
+
+	f, err := fetcher.NewHttpFetcher(recorder)
+	require.NoError(t, err)
+
+	en := fetcher.WithHeaders(map[string]string{"Accept-Language": "en"})
+	de := fetcher.WithHeaders(map[string]string{"Accept-Language": "de"})
+
+	assert.Equal(t, "<html>/page en</html>", fetchBody(t, f, origin+"/page", en))
+	assert.Equal(t, "<html>/page de</html>", fetchBody(t, f, origin+"/page", de))
+
+	resolver, err := robots.NewRobotsResolver(
+		robots.RobotsConfig{UserAgent: "synapse", TTL: time.Hour},
+		robots.NewDefaultRobotsTxtFetcher(http.Client{Transport: recorder}),
+		&mapCache{entries: map[string]*robots.RobotsEntry{}},
+	)
+	require.NoError(t, err)
+	_, err = resolver.Resolve(t.Context(), origin)
+	require.NoError(t, err)
+
+	require.NoError(t, recorder.Save(), "failed to save cassette")
+	assert.Len(t, recorder.Interactions(), 3)
+
+	// No network access from here on
+	server.Close()
+
+	player, err := New(Config{Path: path, Mode: ModeReplay, MatchHeaders: matchHeaders})
+	require.NoError(t, err, "failed to load cassette")
+
+	t.Run("fetcher", func(t *testing.T) {
+		f, err := fetcher.NewHttpFetcher(player)
+		require.NoError(t, err)
+
+		assert.Equal(t, "<html>/page de</html>", fetchBody(t, f, origin+"/page", de))
+		assert.Equal(t, "<html>/page en</html>", fetchBody(t, f, origin+"/page", en))
+
+		_, err = f.Get(t.Context(), origin+"/missing")
+		assert.ErrorIs(t, err, ErrInteractionNotFound)
+	})
+
+	t.Run("robots resolver", func(t *testing.T) {
+		resolver, err := robots.NewRobotsResolver(
+			robots.RobotsConfig{UserAgent: "synapse", TTL: time.Hour},
+			robots.NewDefaultRobotsTxtFetcher(http.Client{Transport: player}),
+			&mapCache{entries: map[string]*robots.RobotsEntry{}},
+		)
+		require.NoError(t, err)
+
+		entry, err := resolver.Resolve(t.Context(), origin)
+		require.NoError(t, err)
+		assert.False(t, entry.Test("/private"))
+		assert.True(t, entry.Test("/page"))
+	})
+}
+
+func TestRedactHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Header().Set("Set-Cookie", "session=secret-session")
+		_, _ = io.WriteString(w, "ok")
+	}))
+	t.Cleanup(server.Close)
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	recorder, err := New(Config{Path: path, Mode: ModeRecord, MatchHeaders: []string{"Authorization"}})
+	require.NoError(t, err)
+
+	f, err := fetcher.NewHttpFetcher(recorder)
+	require.NoError(t, err)
+
+	assert.Equal(t, "ok", fetchBody(t, f, server.URL,
+		fetcher.WithBearerToken("secret-token"),
+		fetcher.WithCookies([]*http.Cookie{{Name: "session", Value: "secret-cookie"}}),
+		fetcher.WithHeaders(map[string]string{"Proxy-Authorization": "Basic secret-proxy"}),
+	))
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	resp, err := recorder.RoundTrip(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, "session=secret-session", resp.Header.Get("Set-Cookie"), "served as received while recording")
+
+	require.NoError(t, recorder.Save())
+
+	data, err := os.ReadFile(path) // #nosec G304 -- within the test directory
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "secret")
+
+	header := recorder.Interactions()[0].Request.Header
+	for _, name := range DefaultRedactHeaders {
+		assert.Equal(t, Redacted, header.Get(name), name)
+	}
+	assert.Equal(t, Redacted, recorder.Interactions()[0].Response.Header.Get("Set-Cookie"))
+
+	player, err := New(Config{Path: path, Mode: ModeReplay, MatchHeaders: []string{"Authorization"}})
+	require.NoError(t, err)
+
+	f, err = fetcher.NewHttpFetcher(player)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", fetchBody(t, f, server.URL, fetcher.WithBearerToken("another-token")))
+}
@@ -5,28 +5,133 @@ package http
 
 import (
 	"bytes"
-	"errors"
+	"fmt"
 	"io"
 	"mime"
 	"net/http"
 	"strings"
 
 	"github.com/saintfish/chardet"
+	"golang.org/x/net/html"
 	"golang.org/x/net/html/charset"
 )
 
 const defaultPeekSize = 1024
 
+// Length of the longest byte order mark (UTF-8)
+const maxBOMSize = 3
+
+// Where the charset of the response was determined from.
+type CharsetSource int
+
+const (
+	// Forced via per-host override
+	CharsetSourceOverride CharsetSource = iota
+
+	// Byte order mark at the beginning of the body
+	CharsetSourceBOM
+
+	// `charset` parameter of the `Content-Type` header
+	CharsetSourceHeader
+
+	// `<meta charset>` or `<meta http-equiv="Content-Type">` tag
+	CharsetSourceMetaTag
+
+	// Heuristic-based detection on the beginning of the body
+	CharsetSourceDetected
+
+	// Configured default encoding, or UTF-8 when nothing else applies
+	CharsetSourceDefault
+)
+
+func (s CharsetSource) String() string {
+	switch s {
+	case CharsetSourceOverride:
+		return "override"
+	case CharsetSourceBOM:
+		return "bom"
+	case CharsetSourceHeader:
+		return "header"
+	case CharsetSourceMetaTag:
+		return "meta"
+	case CharsetSourceDetected:
+		return "detected"
+	case CharsetSourceDefault:
+		return "default"
+	default:
+		return "unknown"
+	}
+}
+
 type CharsetMetadata struct {
 	MIMEType string
 	Charset  string
+	Source   CharsetSource
+
+	// Confidence (0-100) in the charset, 100 for the declared charsets
+	// (override, BOM, header and meta tag), the heuristic confidence for the
+	// detected ones and 0 for the default.
+	Confidence int
+}
+
+// Configures the charset normalization in [HttpFetcher].
+type charsetConfig struct {
+	// Per-host (without port) forced charsets
+	hostCharsets map[string]string
+
+	// Used for the responses without declared charset, instead of heuristic detection
+	defaultEncoding string
+
+	// Skip detection and transcoding altogether
+	raw bool
+}
+
+func (c *charsetConfig) validate() error {
+	if c.defaultEncoding != "" {
+		if _, err := lookupCharset(c.defaultEncoding); err != nil {
+			return err
+		}
+	}
+	for host, label := range c.hostCharsets {
+		if _, err := lookupCharset(label); err != nil {
+			return fmt.Errorf("%w (host: %s)", err, host)
+		}
+	}
+	return nil
+}
+
+// Returns the charset metadata of the response normalized by [HttpFetcher].
+//
+// It's unavailable (false) for non-textual content, responses without body
+// or when the raw mode is enabled.
+func ResponseCharset(resp *http.Response) (*CharsetMetadata, bool) {
+	if resp == nil {
+		return nil, false
+	}
+	if body, ok := resp.Body.(*charsetReadCloser); ok {
+		return body.metadata, true
+	}
+	return nil, false
+}
+
+type charsetReadCloser struct {
+	io.Reader
+	closer   io.Closer
+	metadata *CharsetMetadata
+}
+
+func (c *charsetReadCloser) Close() error {
+	return c.closer.Close()
 }
 
 // Automatically detects the charset and converts to UTF-8 if needed.
 // Returns the original reader unchanged if the content is non-textual.
-func newUTF8WithFallbackReader(resp *http.Response, defaultEncoding string) (io.ReadCloser, error) {
+func newUTF8WithFallbackReader(resp *http.Response, config *charsetConfig) (io.ReadCloser, error) {
+	if config.raw {
+		return resp.Body, nil
+	}
 
-	metadata, err := detectCharset(resp, defaultEncoding)
+	metadata, err := detectCharset(resp, config)
 	if err != nil {
 		return nil, err
 	}
@@ -35,97 +140,224 @@ func newUTF8WithFallbackReader(resp *http.Response, defaultEncoding string) (io.
 		return resp.Body, nil
 	}
 
-	utf8Reader, err := newCharsetReader(resp.Body, metadata.MIMEType, metadata.Charset)
+	utf8Reader, err := newCharsetReader(resp.Body, metadata.Charset)
 	if err != nil {
 		return nil, err
 	}
 
-	return &readCloser{
-		Reader: utf8Reader,
-		closer: resp.Body,
+	return &charsetReadCloser{
+		Reader:   utf8Reader,
+		closer:   resp.Body,
+		metadata: metadata,
 	}, nil
 }
 
-// SAFETY: Ensure that the `defaultEncoding` is valid at the caller side, if provided.
-func detectCharset(resp *http.Response, defaultEncoding string) (*CharsetMetadata, error) {
-
-	detectCharsetFromBody := func(resp *http.Response) (string, error) {
-		buf := make([]byte, defaultPeekSize)
-		n, err := io.ReadFull(resp.Body, buf)
-		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
-			return "", err
-		}
-
-		buf = buf[:n]
-		detector := chardet.NewTextDetector()
-		results, err := detector.DetectAll(buf)
-		if err != nil {
-			return "", err
-		}
-
-		// Restore the body
-		resp.Body = io.NopCloser(io.MultiReader(bytes.NewReader(buf), resp.Body))
-
-		if len(results) == 0 {
-			return "", nil
-		}
-
-		return results[0].Charset, nil
-	}
-
+// Determines the charset in the following order:
+//  1. Per-host override
+//  2. Byte order mark
+//  3. `Content-Type` header
+//  4. Meta tag (for HTML)
+//  5. Default encoding, if configured
+//  6. Heuristic-based detection
+//
+// Falls back to UTF-8. Returns nil for non-textual content.
+func detectCharset(resp *http.Response, config *charsetConfig) (*CharsetMetadata, error) {
 	isTextualContent := func(mimeType string) bool {
 		switch {
 		case strings.HasPrefix(mimeType, "image/"),
 			strings.HasPrefix(mimeType, "video/"),
 			strings.HasPrefix(mimeType, "audio/"),
-			strings.HasPrefix(mimeType, "font/"):
+			strings.HasPrefix(mimeType, "font/"),
+			mimeType == "application/octet-stream",
+			mimeType == "application/pdf",
+			mimeType == "application/zip":
 			return false
 		default:
 			return true
 		}
 	}
 
-	if resp.ContentLength == 0 {
-		return nil, errors.New("empty response body")
+	if !hasBody(resp) {
+		return nil, nil
+	}
+
+	mimeType, params, mediaErr := mime.ParseMediaType(resp.Header.Get(HeaderContentType))
+
+	// The charset declared by the header only gives way to the BOM, hence
+	// the body is peeked for the latter only
+	peekSize := defaultPeekSize
+	if mediaErr == nil && params["charset"] != "" {
+		if _, err := lookupCharset(params["charset"]); err == nil {
+			peekSize = maxBOMSize
+		}
 	}
 
-	// 1. Try detecting from Content-Type header
-	contentType := resp.Header.Get("Content-Type")
-	mimeType, params, err := mime.ParseMediaType(contentType)
+	peek, err := peekBody(resp, peekSize)
 	if err != nil {
-		// mimeType = "text/plain"
 		return nil, err
 	}
-	detectedCharset := strings.ToLower(params["charset"])
+	if len(peek) == 0 {
+		return nil, nil
+	}
+
+	// Sniff the media type, if the header is missing or invalid
+	if mediaErr != nil {
+		mimeType, params, _ = mime.ParseMediaType(http.DetectContentType(peek))
+	}
 
 	if !isTextualContent(mimeType) {
 		return nil, nil
 	}
 
-	if defaultEncoding != "" {
-		detectedCharset = defaultEncoding
+	metadata := func(name string, source CharsetSource, confidence int) *CharsetMetadata {
+		return &CharsetMetadata{
+			MIMEType:   mimeType,
+			Charset:    name,
+			Source:     source,
+			Confidence: confidence,
+		}
+	}
+
+	// 1. Per-host override
+	if resp.Request != nil {
+		if label, ok := config.hostCharsets[strings.ToLower(resp.Request.URL.Hostname())]; ok {
+			if name, err := lookupCharset(label); err == nil {
+				return metadata(name, CharsetSourceOverride, 100), nil
+			}
+		}
+	}
+
+	// 2. Byte order mark, stripped from the body
+	if name, size := bomCharset(peek); name != "" {
+		if _, err := io.CopyN(io.Discard, resp.Body, int64(size)); err != nil {
+			return nil, err
+		}
+		return metadata(name, CharsetSourceBOM, 100), nil
+	}
+
+	// 3. Content-Type header, unknown labels are ignored
+	if label := params["charset"]; label != "" {
+		if name, err := lookupCharset(label); err == nil {
+			return metadata(name, CharsetSourceHeader, 100), nil
+		}
+	}
+
+	// 4. Meta tag
+	if mimeType == "text/html" || mimeType == "application/xhtml+xml" {
+		if label := metaCharset(peek); label != "" {
+			if name, err := lookupCharset(label); err == nil {
+				return metadata(name, CharsetSourceMetaTag, 100), nil
+			}
+		}
 	}
 
-	// 2. Determine charset from body
-	if detectedCharset == "" {
-		detectedCharset, err = detectCharsetFromBody(resp)
+	// 5. Default encoding
+	if config.defaultEncoding != "" {
+		name, err := lookupCharset(config.defaultEncoding)
 		if err != nil {
 			return nil, err
 		}
+		return metadata(name, CharsetSourceDefault, 0), nil
 	}
 
-	return &CharsetMetadata{
-		MIMEType: mimeType,
-		Charset:  detectedCharset,
-	}, nil
+	// 6. Heuristic-based detection
+	detector := chardet.NewTextDetector()
+	if result, err := detector.DetectBest(peek); err == nil && result != nil {
+		if name, err := lookupCharset(result.Charset); err == nil {
+			return metadata(name, CharsetSourceDetected, result.Confidence), nil
+		}
+	}
+
+	return metadata("utf-8", CharsetSourceDefault, 0), nil
+}
+
+// Reads up to `n` bytes from the beginning of the body, and restores the body.
+func peekBody(resp *http.Response, n int) ([]byte, error) {
+	buf := make([]byte, n)
+	read, err := io.ReadFull(resp.Body, buf)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, err
+	}
+	buf = buf[:read]
+
+	resp.Body = &readCloser{
+		Reader: io.MultiReader(bytes.NewReader(buf), resp.Body),
+		closer: resp.Body,
+	}
+
+	return buf, nil
+}
+
+// Returns the charset of the byte order mark, along with its length.
+func bomCharset(peek []byte) (string, int) {
+	switch {
+	case bytes.HasPrefix(peek, []byte{0xEF, 0xBB, 0xBF}):
+		return "utf-8", 3
+	case bytes.HasPrefix(peek, []byte{0xFE, 0xFF}):
+		return "utf-16be", 2
+	case bytes.HasPrefix(peek, []byte{0xFF, 0xFE}):
+		return "utf-16le", 2
+	default:
+		return "", 0
+	}
+}
+
+// Scans the tags within the beginning of the HTML document for
+// `<meta charset>` or `<meta http-equiv="Content-Type" content="...">`.
+func metaCharset(peek []byte) string {
+	tokenizer := html.NewTokenizer(bytes.NewReader(peek))
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return ""
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := tokenizer.TagName()
+			if string(name) != "meta" || !hasAttr {
+				continue
+			}
+
+			var httpEquiv, content string
+			for hasAttr {
+				var key, val []byte
+				key, val, hasAttr = tokenizer.TagAttr()
+				switch strings.ToLower(string(key)) {
+				case "charset":
+					return strings.TrimSpace(string(val))
+				case "http-equiv":
+					httpEquiv = strings.ToLower(string(val))
+				case "content":
+					content = string(val)
+				}
+			}
+
+			if httpEquiv == "content-type" {
+				if _, params, err := mime.ParseMediaType(content); err == nil && params["charset"] != "" {
+					return params["charset"]
+				}
+			}
+		}
+	}
+}
+
+// Resolves the label to its canonical (WHATWG) charset name.
+func lookupCharset(label string) (string, error) {
+	enc, name := charset.Lookup(label)
+	if enc == nil {
+		return "", fmt.Errorf("unsupported charset: %q", label)
+	}
+	return name, nil
 }
 
 // Converts from the given charset to UTF-8.
-func newCharsetReader(r io.Reader, mimeType, charsetName string) (io.Reader, error) {
+func newCharsetReader(r io.Reader, charsetName string) (io.Reader, error) {
 	if strings.EqualFold(charsetName, "utf-8") || charsetName == "" {
 		return r, nil
 	}
 
-	contentType := mimeType + "; charset=" + charsetName
-	return charset.NewReader(r, contentType)
+	enc, _ := charset.Lookup(charsetName)
+	if enc == nil {
+		return nil, fmt.Errorf("unsupported charset: %q", charsetName)
+	}
+	return enc.NewDecoder().Reader(r), nil
 }
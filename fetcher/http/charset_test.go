@@ -0,0 +1,126 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCharsetDetection(t *testing.T) {
+	// "café" in windows-1252
+	latin1 := "caf\xe9"
+
+	responses := map[string]struct {
+		contentType string
+		body        string
+	}{
+		"/header":     {"text/plain; charset=ISO-8859-1", latin1},
+		"/meta":       {"text/html", `<html><head><meta charset="windows-1252"></head><body>` + latin1 + `</body></html>`},
+		"/bom":        {"text/plain; charset=ISO-8859-1", "\xef\xbb\xbfcafé"},
+		"/utf16":      {"text/plain", "\xff\xfec\x00a\x00f\x00\xe9\x00"},
+		"/undeclared": {"text/plain", latin1},
+		"/image":      {"image/png", "\x89PNG"},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := responses[r.URL.Path]
+		w.Header().Set("Content-Type", resp.contentType)
+		_, _ = io.WriteString(w, resp.body)
+	}))
+	t.Cleanup(server.Close)
+
+	get := func(t *testing.T, f *HttpFetcher, path string) (string, *CharsetMetadata) {
+		t.Helper()
+
+		resp, err := f.Get(t.Context(), server.URL+path)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = resp.Body.Close() })
+
+		metadata, _ := ResponseCharset(resp)
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		return string(body), metadata
+	}
+
+	testCases := []struct {
+		name       string
+		path       string
+		body       string
+		charset    string
+		opts       []HttpFetcherOptions
+		source     CharsetSource
+		hasCharset bool
+	}{
+		{name: "header", path: "/header", body: "café", charset: "windows-1252", source: CharsetSourceHeader, hasCharset: true},
+		{name: "meta tag", path: "/meta", charset: "windows-1252", source: CharsetSourceMetaTag, hasCharset: true},
+		{name: "bom wins over header", path: "/bom", body: "café", charset: "utf-8", source: CharsetSourceBOM, hasCharset: true},
+		{name: "utf-16 bom", path: "/utf16", body: "café", charset: "utf-16le", source: CharsetSourceBOM, hasCharset: true},
+		{name: "default encoding", path: "/undeclared", opts: []HttpFetcherOptions{WithDefaultEncoding("latin1")}, body: "café", charset: "windows-1252", source: CharsetSourceDefault, hasCharset: true},
+		{name: "host override", path: "/header", opts: []HttpFetcherOptions{WithHostCharset("127.0.0.1", "utf-8")}, body: latin1, charset: "utf-8", source: CharsetSourceOverride, hasCharset: true},
+		{name: "raw mode", path: "/header", opts: []HttpFetcherOptions{WithRawBody()}, body: latin1},
+		{name: "non-textual", path: "/image", body: "\x89PNG"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := NewHttpFetcher(server.Client(), tc.opts...)
+			require.NoError(t, err)
+
+			body, metadata := get(t, f, tc.path)
+			if tc.body != "" {
+				assert.Equal(t, tc.body, body)
+			}
+
+			if !tc.hasCharset {
+				assert.Nil(t, metadata)
+				return
+			}
+			require.NotNil(t, metadata)
+			assert.Equal(t, tc.charset, metadata.Charset)
+			assert.Equal(t, tc.source, metadata.Source)
+		})
+	}
+
+	t.Run("declared charset", func(t *testing.T) {
+		// The rest of the body is sent only after the response is returned,
+		// hence the fetcher mustn't wait for more than the BOM
+		release := make(chan struct{})
+		stalled := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/plain; charset=ISO-8859-1")
+			_, _ = io.WriteString(w, "caf")
+			w.(http.Flusher).Flush()
+			<-release
+			_, _ = io.WriteString(w, "\xe9")
+		}))
+		t.Cleanup(stalled.Close)
+
+		f, err := NewHttpFetcher(stalled.Client())
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(t.Context(), time.Second)
+		defer cancel()
+		resp, err := f.Get(ctx, stalled.URL)
+		close(release)
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "café", string(body))
+	})
+
+	t.Run("invalid default encoding", func(t *testing.T) {
+		_, err := NewHttpFetcher(server.Client(), WithDefaultEncoding("not-a-charset"))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,412 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package cookies
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ritvikos/synapse/frontier/backend"
+	"golang.org/x/net/publicsuffix"
+)
+
+const defaultSession = "default"
+
+// Entry is a single persisted cookie.
+type Entry struct {
+	// Zero for session cookies
+	Expires time.Time `json:"expires"`
+
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Domain string `json:"domain"`
+	Path   string `json:"path"`
+
+	Secure   bool `json:"secure"`
+	HttpOnly bool `json:"http_only"`
+
+	// Sent only to the exact domain, i.e. the `Domain` attribute was absent
+	HostOnly bool `json:"host_only"`
+}
+
+func (e *Entry) expired(now time.Time) bool {
+	return !e.Expires.IsZero() && !e.Expires.After(now)
+}
+
+// Store persists the cookies of each session, keyed by the session ID.
+type Store = backend.Store[[]Entry]
+
+type Options struct {
+	// Defaults to [publicsuffix.List]
+	PublicSuffixList cookiejar.PublicSuffixList
+
+	// Called when the cookies couldn't be loaded from or persisted to the store,
+	// as [http.CookieJar] methods cannot return errors.
+	OnError func(err error)
+}
+
+// Jar is an [http.CookieJar] backed by the [Store], so that the cookies
+// (eg: logged-in sessions) survive restarts and can be shared between
+// crawler processes.
+//
+// Cookies are loaded lazily on first use and persisted on every change, the
+// change is applied to the latest stored state of the session, so that the
+// jars (or processes) sharing the store don't drop each other's cookies.
+// Session cookies (without expiry) are persisted as well.
+//
+// # Example Usage
+//
+//	jar, err := cookies.NewFileJar("/var/lib/synapse/cookies", cookies.Options{})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//
+//	// Isolated jar per task/account
+//	f, err := http.NewHttpFetcher(client, http.WithCookieJar(jar.Session("account-1")))
+type Jar struct {
+	store    Store
+	sessions *sessions
+	options  Options
+	session  string
+	entries  []Entry
+	mu       sync.Mutex
+	loaded   bool
+}
+
+// Jars of the sessions derived from the same jar, one per session ID.
+type sessions struct {
+	jars map[string]*Jar
+	mu   sync.Mutex
+}
+
+func NewJar(store Store, options Options) *Jar {
+	if options.PublicSuffixList == nil {
+		options.PublicSuffixList = publicsuffix.List
+	}
+	if options.OnError == nil {
+		options.OnError = func(error) {}
+	}
+
+	jar := &Jar{
+		store:    store,
+		options:  options,
+		session:  defaultSession,
+		sessions: &sessions{jars: make(map[string]*Jar)},
+	}
+	jar.sessions.jars[defaultSession] = jar
+	return jar
+}
+
+// Jar persisting the cookies as Netscape `cookies.txt` files (one per session) in the directory.
+func NewFileJar(dir string, options Options) (*Jar, error) {
+	store, err := NewFileStore(dir)
+	if err != nil {
+		return nil, err
+	}
+	return NewJar(store, options), nil
+}
+
+// Returns an isolated jar for the session, sharing the same store.
+// The same jar is returned for the same session ID.
+func (j *Jar) Session(id string) *Jar {
+	if id == "" {
+		id = defaultSession
+	}
+
+	j.sessions.mu.Lock()
+	defer j.sessions.mu.Unlock()
+
+	jar, ok := j.sessions.jars[id]
+	if !ok {
+		jar = &Jar{
+			store:    j.store,
+			sessions: j.sessions,
+			options:  j.options,
+			session:  id,
+		}
+		j.sessions.jars[id] = jar
+	}
+	return jar
+}
+
+func (j *Jar) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	if len(cookies) == 0 || (u.Scheme != "http" && u.Scheme != "https") {
+		return
+	}
+
+	host, err := canonicalHost(u.Host)
+	if err != nil {
+		return
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.reload()
+
+	now := time.Now()
+	changed := j.purgeExpired(now)
+
+	for _, cookie := range cookies {
+		entry, remove, ok := j.newEntry(cookie, u, host, now)
+		if !ok {
+			continue
+		}
+
+		idx := slices.IndexFunc(j.entries, func(e Entry) bool {
+			return e.Name == entry.Name && e.Domain == entry.Domain && e.Path == entry.Path
+		})
+
+		switch {
+		case remove && idx >= 0:
+			j.entries = slices.Delete(j.entries, idx, idx+1)
+			changed = true
+		case remove:
+		case idx >= 0:
+			j.entries[idx] = entry
+			changed = true
+		default:
+			j.entries = append(j.entries, entry)
+			changed = true
+		}
+	}
+
+	if changed {
+		j.persist()
+	}
+}
+
+func (j *Jar) Cookies(u *url.URL) []*http.Cookie {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil
+	}
+
+	host, err := canonicalHost(u.Host)
+	if err != nil {
+		return nil
+	}
+
+	path := u.Path
+	if path == "" {
+		path = "/"
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.load()
+
+	now := time.Now()
+	if j.purgeExpired(now) {
+		// Purged from the latest stored state
+		j.reload()
+		j.purgeExpired(now)
+		j.persist()
+	}
+
+	var matched []Entry
+	for _, entry := range j.entries {
+		if entry.Secure && u.Scheme != "https" {
+			continue
+		}
+		if !domainMatch(&entry, host) || !pathMatch(entry.Path, path) {
+			continue
+		}
+		matched = append(matched, entry)
+	}
+
+	// Cookies with longer paths are listed first (RFC 6265, section 5.4)
+	slices.SortStableFunc(matched, func(a, b Entry) int {
+		return len(b.Path) - len(a.Path)
+	})
+
+	cookies := make([]*http.Cookie, 0, len(matched))
+	for _, entry := range matched {
+		cookies = append(cookies, &http.Cookie{Name: entry.Name, Value: entry.Value})
+	}
+	return cookies
+}
+
+// Snapshot of the unexpired cookies within the session.
+func (j *Jar) Entries() []Entry {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.load()
+	j.purgeExpired(time.Now())
+	return slices.Clone(j.entries)
+}
+
+// Imports the cookies in Netscape `cookies.txt` format into the session.
+func (j *Jar) Import(r io.Reader) error {
+	entries, err := ReadNetscape(r)
+	if err != nil {
+		return err
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.reload()
+
+	for _, entry := range entries {
+		idx := slices.IndexFunc(j.entries, func(e Entry) bool {
+			return e.Name == entry.Name && e.Domain == entry.Domain && e.Path == entry.Path
+		})
+		if idx >= 0 {
+			j.entries[idx] = entry
+		} else {
+			j.entries = append(j.entries, entry)
+		}
+	}
+	j.purgeExpired(time.Now())
+
+	return j.store.Put(context.Background(), j.session, j.entries)
+}
+
+// Exports the cookies of the session in Netscape `cookies.txt` format.
+func (j *Jar) Export(w io.Writer) error {
+	return WriteNetscape(w, j.Entries())
+}
+
+// Converts the cookie received from `u` into an entry, as per RFC 6265 (section 5.3).
+// Returns remove=true if the cookie deletes an existing one, or ok=false if it must be ignored.
+func (j *Jar) newEntry(cookie *http.Cookie, u *url.URL, host string, now time.Time) (entry Entry, remove, ok bool) {
+	entry = Entry{
+		Name:     cookie.Name,
+		Value:    cookie.Value,
+		Secure:   cookie.Secure,
+		HttpOnly: cookie.HttpOnly,
+	}
+
+	if cookie.Path == "" || cookie.Path[0] != '/' {
+		entry.Path = defaultPath(u.Path)
+	} else {
+		entry.Path = cookie.Path
+	}
+
+	domain := strings.TrimPrefix(strings.ToLower(cookie.Domain), ".")
+	switch {
+	case domain == "" || domain == host:
+		entry.Domain = host
+		entry.HostOnly = domain == "" || isIP(host)
+
+	case isIP(host):
+		// Domain attribute is not allowed for IP addresses
+		return entry, false, false
+
+	case !strings.HasSuffix(host, "."+domain):
+		return entry, false, false
+
+	default:
+		// Reject domains like ".com" or ".co.uk"
+		if ps := j.options.PublicSuffixList.PublicSuffix(domain); ps == domain {
+			return entry, false, false
+		}
+		entry.Domain = domain
+	}
+
+	switch {
+	case cookie.MaxAge < 0:
+		return entry, true, true
+	case cookie.MaxAge > 0:
+		entry.Expires = now.Add(time.Duration(cookie.MaxAge) * time.Second)
+	case !cookie.Expires.IsZero():
+		if !cookie.Expires.After(now) {
+			return entry, true, true
+		}
+		entry.Expires = cookie.Expires
+	}
+
+	return entry, false, true
+}
+
+// Loads the session from the store, once.
+func (j *Jar) load() {
+	if !j.loaded {
+		j.reload()
+	}
+}
+
+// Loads the latest state of the session from the store, the loaded entries
+// are retained if the store fails.
+func (j *Jar) reload() {
+	j.loaded = true
+
+	entries, err := j.store.Get(context.Background(), j.session)
+	switch {
+	case errors.Is(err, backend.ErrNotFound):
+		j.entries = nil
+	case err != nil:
+		j.options.OnError(fmt.Errorf("cookie jar: failed to load session %q: %w", j.session, err))
+	default:
+		j.entries = slices.Clone(entries)
+	}
+}
+
+func (j *Jar) persist() {
+	if err := j.store.Put(context.Background(), j.session, j.entries); err != nil {
+		j.options.OnError(fmt.Errorf("cookie jar: failed to persist session %q: %w", j.session, err))
+	}
+}
+
+func (j *Jar) purgeExpired(now time.Time) bool {
+	n := len(j.entries)
+	j.entries = slices.DeleteFunc(j.entries, func(e Entry) bool {
+		return e.expired(now)
+	})
+	return len(j.entries) != n
+}
+
+func domainMatch(entry *Entry, host string) bool {
+	if entry.Domain == host {
+		return true
+	}
+	return !entry.HostOnly && strings.HasSuffix(host, "."+entry.Domain)
+}
+
+func pathMatch(cookiePath, requestPath string) bool {
+	if requestPath == cookiePath {
+		return true
+	}
+	if !strings.HasPrefix(requestPath, cookiePath) {
+		return false
+	}
+	return strings.HasSuffix(cookiePath, "/") || requestPath[len(cookiePath)] == '/'
+}
+
+// Directory of the request path (RFC 6265, section 5.1.4)
+func defaultPath(path string) string {
+	if path == "" || path[0] != '/' {
+		return "/"
+	}
+	i := strings.LastIndex(path, "/")
+	if i == 0 {
+		return "/"
+	}
+	return path[:i]
+}
+
+func canonicalHost(host string) (string, error) {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "" {
+		return "", errors.New("cookie jar: empty host")
+	}
+	return host, nil
+}
+
+func isIP(host string) bool {
+	return net.ParseIP(strings.Trim(host, "[]")) != nil
+}
+
+var _ http.CookieJar = (*Jar)(nil)
@@ -0,0 +1,159 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package cookies
+
+import (
+	"bytes"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJar(t *testing.T) {
+	u, err := url.Parse("https://www.example.com/account/login")
+	require.NoError(t, err)
+
+	names := func(cookies []*http.Cookie) []string {
+		var out []string
+		for _, c := range cookies {
+			out = append(out, c.Name)
+		}
+		return out
+	}
+
+	t.Run("persists across restarts", func(t *testing.T) {
+		dir := t.TempDir()
+
+		jar, err := NewFileJar(dir, Options{})
+		require.NoError(t, err)
+
+		jar.SetCookies(u, []*http.Cookie{
+			{Name: "session", Value: "abc", HttpOnly: true},
+			{Name: "pref", Value: "dark", Domain: "example.com", Path: "/", MaxAge: 3600},
+			{Name: "tracker", Value: "x", Domain: "com"},
+		})
+
+		reopened, err := NewFileJar(dir, Options{})
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []string{"session", "pref"}, names(reopened.Cookies(u)))
+
+		other, _ := url.Parse("https://api.example.com/")
+		assert.Equal(t, []string{"pref"}, names(reopened.Cookies(other)))
+	})
+
+	t.Run("isolated sessions", func(t *testing.T) {
+		jar, err := NewFileJar(t.TempDir(), Options{})
+		require.NoError(t, err)
+
+		jar.Session("a").SetCookies(u, []*http.Cookie{{Name: "token", Value: "a"}})
+		jar.Session("b").SetCookies(u, []*http.Cookie{{Name: "token", Value: "b"}})
+
+		assert.Equal(t, "a", jar.Session("a").Cookies(u)[0].Value)
+		assert.Equal(t, "b", jar.Session("b").Cookies(u)[0].Value)
+		assert.Empty(t, jar.Cookies(u))
+	})
+
+	t.Run("shared session", func(t *testing.T) {
+		jar, err := NewFileJar(t.TempDir(), Options{})
+		require.NoError(t, err)
+
+		assert.Same(t, jar.Session("a"), jar.Session("a"))
+		assert.Same(t, jar, jar.Session(""))
+	})
+
+	t.Run("writers sharing the store", func(t *testing.T) {
+		dir := t.TempDir()
+
+		first, err := NewFileJar(dir, Options{})
+		require.NoError(t, err)
+		second, err := NewFileJar(dir, Options{})
+		require.NoError(t, err)
+
+		// Both loaded before the other one's write
+		assert.Empty(t, first.Cookies(u))
+		assert.Empty(t, second.Cookies(u))
+
+		first.SetCookies(u, []*http.Cookie{{Name: "first", Value: "1"}})
+		second.SetCookies(u, []*http.Cookie{{Name: "second", Value: "2"}})
+
+		reopened, err := NewFileJar(dir, Options{})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"first", "second"}, names(reopened.Cookies(u)))
+	})
+
+	t.Run("deletes expired cookies", func(t *testing.T) {
+		jar, err := NewFileJar(t.TempDir(), Options{})
+		require.NoError(t, err)
+
+		jar.SetCookies(u, []*http.Cookie{{Name: "token", Value: "a"}})
+		jar.SetCookies(u, []*http.Cookie{{Name: "token", MaxAge: -1}})
+
+		assert.Empty(t, jar.Cookies(u))
+	})
+
+	t.Run("invalid session key", func(t *testing.T) {
+		var errs []error
+		jar, err := NewFileJar(t.TempDir(), Options{OnError: func(err error) { errs = append(errs, err) }})
+		require.NoError(t, err)
+
+		jar.Session("../escape").SetCookies(u, []*http.Cookie{{Name: "token", Value: "a"}})
+		assert.NotEmpty(t, errs)
+	})
+}
+
+func TestNetscape(t *testing.T) {
+	input := strings.Join([]string{
+		"# Netscape HTTP Cookie File",
+		"",
+		".example.com\tTRUE\t/\tTRUE\t0\tpref\tdark",
+		"#HttpOnly_www.example.com\tFALSE\t/account\tFALSE\t4102444800\tsession\tabc",
+		"www.example.com\tFALSE\t/\tFALSE\t0\tempty",
+	}, "\n")
+
+	entries, err := ReadNetscape(strings.NewReader(input))
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+
+	assert.Equal(t, Entry{Name: "pref", Value: "dark", Domain: "example.com", Path: "/", Secure: true}, entries[0])
+	assert.Equal(t, Entry{
+		Expires:  time.Unix(4102444800, 0),
+		Name:     "session",
+		Value:    "abc",
+		Domain:   "www.example.com",
+		Path:     "/account",
+		HttpOnly: true,
+		HostOnly: true,
+	}, entries[1])
+	assert.Empty(t, entries[2].Value)
+
+	t.Run("round trip", func(t *testing.T) {
+		jar := NewJar(mustFileStore(t), Options{})
+		require.NoError(t, jar.Import(strings.NewReader(input)))
+
+		var buf bytes.Buffer
+		require.NoError(t, jar.Export(&buf))
+
+		exported, err := ReadNetscape(&buf)
+		require.NoError(t, err)
+		assert.Equal(t, entries, exported)
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		_, err := ReadNetscape(strings.NewReader("example.com\tTRUE\t/"))
+		assert.Error(t, err)
+	})
+}
+
+func mustFileStore(t *testing.T) *FileStore {
+	t.Helper()
+	store, err := NewFileStore(t.TempDir())
+	require.NoError(t, err)
+	return store
+}
@@ -0,0 +1,118 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package cookies
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const httpOnlyPrefix = "#HttpOnly_"
+
+// Parses the cookies in Netscape `cookies.txt` format (as used by curl and wget).
+//
+// Each line contains tab-separated fields: domain, include subdomains,
+// path, secure, expiry (unix seconds, 0 for session cookies), name and value.
+func ReadNetscape(r io.Reader) ([]Entry, error) {
+	var entries []Entry
+
+	scanner := bufio.NewScanner(r)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimRight(scanner.Text(), "\r")
+
+		httpOnly := strings.HasPrefix(line, httpOnlyPrefix)
+		if httpOnly {
+			line = strings.TrimPrefix(line, httpOnlyPrefix)
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Split(line, "\t")
+		if len(fields) == 6 {
+			// Cookies with empty value
+			fields = append(fields, "")
+		}
+		if len(fields) != 7 {
+			return nil, fmt.Errorf("cookies.txt: line %d: expected 7 fields, got %d", lineNo, len(fields))
+		}
+
+		expiry, err := strconv.ParseInt(fields[4], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("cookies.txt: line %d: invalid expiry: %w", lineNo, err)
+		}
+
+		entry := Entry{
+			Domain:   strings.TrimPrefix(strings.ToLower(fields[0]), "."),
+			HostOnly: !strings.EqualFold(fields[1], "TRUE"),
+			Path:     fields[2],
+			Secure:   strings.EqualFold(fields[3], "TRUE"),
+			Name:     fields[5],
+			Value:    fields[6],
+			HttpOnly: httpOnly,
+		}
+		if expiry > 0 {
+			entry.Expires = time.Unix(expiry, 0)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// Writes the cookies in Netscape `cookies.txt` format.
+func WriteNetscape(w io.Writer, entries []Entry) error {
+	bw := bufio.NewWriter(w)
+
+	if _, err := bw.WriteString("# Netscape HTTP Cookie File\n"); err != nil {
+		return err
+	}
+
+	formatBool := func(b bool) string {
+		if b {
+			return "TRUE"
+		}
+		return "FALSE"
+	}
+
+	for _, entry := range entries {
+		domain := entry.Domain
+		if !entry.HostOnly {
+			domain = "." + domain
+		}
+		if entry.HttpOnly {
+			domain = httpOnlyPrefix + domain
+		}
+
+		var expiry int64
+		if !entry.Expires.IsZero() {
+			expiry = entry.Expires.Unix()
+		}
+
+		line := strings.Join([]string{
+			domain,
+			formatBool(!entry.HostOnly),
+			entry.Path,
+			formatBool(entry.Secure),
+			strconv.FormatInt(expiry, 10),
+			entry.Name,
+			entry.Value,
+		}, "\t")
+
+		if _, err := bw.WriteString(line + "\n"); err != nil {
+			return err
+		}
+	}
+
+	return bw.Flush()
+}
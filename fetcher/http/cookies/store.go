@@ -0,0 +1,114 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package cookies
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/ritvikos/synapse/frontier/backend"
+)
+
+const filePermissions os.FileMode = 0600
+
+// FileStore persists each session as a Netscape `cookies.txt` file,
+// named `<session>.txt` in the directory, so that the files can be
+// shared with other tools (eg: curl, wget).
+type FileStore struct {
+	dir string
+	mu  sync.Mutex
+}
+
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, fmt.Errorf("cookie file store: %w", err)
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+func (s *FileStore) Put(_ context.Context, key string, value []Entry) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := WriteNetscape(&buf, value); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tmp, err := os.CreateTemp(s.dir, "*.tmp")
+	if err != nil {
+		return fmt.Errorf("cookie file store: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("cookie file store: %w", err)
+	}
+	if err := tmp.Chmod(filePermissions); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("cookie file store: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("cookie file store: %w", err)
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func (s *FileStore) Get(_ context.Context, key string) ([]Entry, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, err := os.Open(path) // #nosec G304 -- the path is validated to be within the directory
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, backend.ErrNotFound
+		}
+		return nil, fmt.Errorf("cookie file store: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	return ReadNetscape(file)
+}
+
+func (s *FileStore) Delete(_ context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("cookie file store: %w", err)
+	}
+	return nil
+}
+
+func (s *FileStore) path(key string) (string, error) {
+	name := key + ".txt"
+	if !filepath.IsLocal(name) || filepath.Base(name) != name {
+		return "", fmt.Errorf("cookie file store: invalid session key %q", key)
+	}
+	return filepath.Join(s.dir, name), nil
+}
+
+var _ Store = (*FileStore)(nil)
@@ -4,8 +4,10 @@
 package http
 
 import (
+	"bufio"
 	"compress/flate"
 	"compress/gzip"
+	"compress/zlib"
 	"fmt"
 	"io"
 	"net/http"
@@ -15,56 +17,138 @@ import (
 	"github.com/klauspost/compress/zstd"
 )
 
+// Content codings supported by [decompressResponse], sent as
+// `Accept-Encoding` unless the request already specifies one.
+const acceptEncoding = "gzip, deflate, br, zstd"
+
 func decompressResponse(resp *http.Response) error {
-	if resp.Body == nil {
+	if resp.Body == nil || !hasBody(resp) {
 		return nil
 	}
 
-	encoding := strings.ToLower(strings.TrimSpace(resp.Header.Get(HeaderContentEncoding)))
-	if encoding == "" || encoding == "identity" {
+	codings := parseContentEncoding(resp.Header.Values(HeaderContentEncoding))
+	if len(codings) == 0 {
 		return nil
 	}
 
-	var reader io.ReadCloser
-	var err error
+	// Codings are listed in the order they were applied, so unwrap in reverse.
+	for i := len(codings) - 1; i >= 0; i-- {
+		reader, err := newDecoder(codings[i], resp.Body)
+		if err != nil {
+			return err
+		}
+		resp.Body = reader
+	}
+
+	resp.ContentLength = -1
+	resp.Header.Del(HeaderContentEncoding)
+	resp.Uncompressed = true
+
+	return nil
+}
+
+// Splits the (possibly repeated) header into the list of codings,
+// excluding `identity`.
+func parseContentEncoding(values []string) []string {
+	var codings []string
+	for _, value := range values {
+		for coding := range strings.SplitSeq(value, ",") {
+			coding = strings.ToLower(strings.TrimSpace(coding))
+			if coding == "" || coding == "identity" {
+				continue
+			}
+			codings = append(codings, coding)
+		}
+	}
+	return codings
+}
+
+// Responses to HEAD requests, 204 and 304 never contain a body,
+// even when the `Content-Encoding` header is present.
+func hasBody(resp *http.Response) bool {
+	if resp.StatusCode == http.StatusNoContent || resp.StatusCode == http.StatusNotModified {
+		return false
+	}
+	if resp.Request != nil && resp.Request.Method == http.MethodHead {
+		return false
+	}
+	return resp.ContentLength != 0
+}
 
-	switch encoding {
-	case "gzip":
-		reader, err = gzip.NewReader(resp.Body)
+func newDecoder(coding string, body io.ReadCloser) (io.ReadCloser, error) {
+	switch coding {
+	case "gzip", "x-gzip":
+		gzipReader, err := gzip.NewReader(body)
 		if err != nil {
-			return fmt.Errorf("failed to create gzip reader: %w", err)
+			return nil, fmt.Errorf("failed to create gzip reader: %w", err)
 		}
+		return &decoderReadCloser{
+			ReadCloser: gzipReader,
+			body:       body,
+		}, nil
 
 	case "br":
-		brotliReader := brotli.NewReader(resp.Body)
-		reader = &readCloser{
-			Reader: brotliReader,
-			closer: resp.Body,
-		}
+		return &readCloser{
+			Reader: brotli.NewReader(body),
+			closer: body,
+		}, nil
 
 	case "zstd":
-		zstdReader, err := zstd.NewReader(resp.Body)
+		zstdReader, err := zstd.NewReader(body)
 		if err != nil {
-			return fmt.Errorf("failed to create zstd reader: %w", err)
+			return nil, fmt.Errorf("failed to create zstd reader: %w", err)
 		}
-		reader = &zstdReadCloser{
+		return &zstdReadCloser{
 			Decoder: zstdReader,
-			body:    resp.Body,
-		}
+			body:    body,
+		}, nil
 
 	case "deflate":
-		reader = flate.NewReader(resp.Body)
+		return newDeflateReader(body)
 
 	default:
-		return fmt.Errorf("unsupported content encoding: %s", encoding)
+		return nil, fmt.Errorf("unsupported content encoding: %s", coding)
 	}
+}
 
-	resp.Body = reader
-	resp.ContentLength = -1
-	resp.Header.Del(HeaderContentEncoding)
-	resp.Uncompressed = true
+// The "deflate" coding is defined as zlib-wrapped (RFC 1950) stream, however
+// some servers send raw deflate (RFC 1951) streams, detected via zlib header.
+func newDeflateReader(body io.ReadCloser) (io.ReadCloser, error) {
+	buffered := bufio.NewReader(body)
+	source := &readCloser{
+		Reader: buffered,
+		closer: body,
+	}
 
-	return nil
+	header, err := buffered.Peek(2)
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to read deflate header: %w", err)
+	}
+
+	if isZlibHeader(header) {
+		zlibReader, err := zlib.NewReader(buffered)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create zlib reader: %w", err)
+		}
+		return &decoderReadCloser{
+			ReadCloser: zlibReader,
+			body:       source,
+		}, nil
+	}
+
+	return &decoderReadCloser{
+		ReadCloser: flate.NewReader(buffered),
+		body:       source,
+	}, nil
+}
+
+// CM (compression method) must be 8 (deflate) and the header checksum must be valid.
+func isZlibHeader(header []byte) bool {
+	if len(header) < 2 {
+		return false
+	}
+	cmf, flg := header[0], header[1]
+	return cmf&0x0f == 8 && (uint16(cmf)<<8|uint16(flg))%31 == 0
 }
 
 type zstdReadCloser struct {
@@ -0,0 +1,117 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encode(t *testing.T, coding string, data []byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	var w io.WriteCloser
+	var err error
+
+	switch coding {
+	case "gzip", "x-gzip":
+		w = gzip.NewWriter(&buf)
+	case "zlib":
+		w = zlib.NewWriter(&buf)
+	case "raw-deflate":
+		w, err = flate.NewWriter(&buf, flate.DefaultCompression)
+	case "br":
+		w = brotli.NewWriter(&buf)
+	case "zstd":
+		w, err = zstd.NewWriter(&buf)
+	default:
+		t.Fatalf("unknown coding %s", coding)
+	}
+	require.NoError(t, err)
+
+	_, err = w.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	return buf.Bytes()
+}
+
+func TestDecompressResponse(t *testing.T) {
+	payload := []byte("<html><body>stacked content codings</body></html>")
+
+	testCases := []struct {
+		name   string
+		header string
+		// Applied in order
+		codings []string
+	}{
+		{name: "identity", header: "identity"},
+		{name: "gzip", header: "gzip", codings: []string{"gzip"}},
+		{name: "x-gzip", header: "x-gzip", codings: []string{"x-gzip"}},
+		{name: "zlib deflate", header: "deflate", codings: []string{"zlib"}},
+		{name: "raw deflate", header: "deflate", codings: []string{"raw-deflate"}},
+		{name: "stacked", header: "gzip, br", codings: []string{"gzip", "br"}},
+		{name: "stacked with identity", header: "zstd, identity, GZIP", codings: []string{"zstd", "gzip"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			body := payload
+			for _, coding := range tc.codings {
+				body = encode(t, coding, body)
+			}
+
+			resp := &http.Response{
+				StatusCode:    http.StatusOK,
+				Header:        http.Header{HeaderContentEncoding: {tc.header}},
+				Body:          io.NopCloser(bytes.NewReader(body)),
+				ContentLength: int64(len(body)),
+			}
+
+			require.NoError(t, decompressResponse(resp))
+			decoded, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+
+			assert.Equal(t, payload, decoded)
+			if len(tc.codings) > 0 {
+				assert.Empty(t, resp.Header.Get(HeaderContentEncoding))
+			}
+		})
+	}
+
+	t.Run("unsupported", func(t *testing.T) {
+		resp := &http.Response{
+			StatusCode:    http.StatusOK,
+			Header:        http.Header{HeaderContentEncoding: {"compress"}},
+			Body:          io.NopCloser(bytes.NewReader(payload)),
+			ContentLength: -1,
+		}
+		assert.Error(t, decompressResponse(resp))
+	})
+
+	t.Run("head request", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodHead, "http://example.com", nil)
+		require.NoError(t, err)
+
+		resp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{HeaderContentEncoding: {"gzip"}},
+			Body:       http.NoBody,
+			Request:    req,
+		}
+		assert.NoError(t, decompressResponse(resp))
+	})
+}
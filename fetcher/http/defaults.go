@@ -6,6 +6,7 @@ package http
 import (
 	"net/http"
 	"net/url"
+	"time"
 )
 
 var NoopEventHook = EventHooks{
@@ -13,6 +14,10 @@ var NoopEventHook = EventHooks{
 	OnResponse: func(*http.Response) {},
 	OnError:    func(*http.Request, error) {},
 	OnChunk:    func([]byte) {},
+
+	OnCircuitStateChange: func(string, CircuitState, CircuitState) {},
+	OnTrace:              func(*http.Request, *Trace) {},
+	OnThrottle:           func(*http.Request, time.Duration) {},
 }
 
 type NoopCookieJar struct{}
@@ -0,0 +1,334 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+var ErrAddressBlocked = errors.New("dns resolver: address blocked")
+
+// HostLookup resolves the host to its addresses, along with the TTL for which
+// the answer can be cached (zero if unknown).
+type HostLookup interface {
+	LookupHost(ctx context.Context, host string) ([]netip.Addr, time.Duration, error)
+}
+
+// Resolves via [net.Resolver] (i.e. the system resolver by default),
+// which doesn't expose the record TTLs.
+type SystemLookup struct {
+	Resolver *net.Resolver
+}
+
+func (s SystemLookup) LookupHost(ctx context.Context, host string) ([]netip.Addr, time.Duration, error) {
+	resolver := s.Resolver
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	addrs, err := resolver.LookupNetIP(ctx, "ip", host)
+	return addrs, 0, err
+}
+
+type DNSResolverConfig struct {
+	// Defaults to [SystemLookup]
+	Lookup HostLookup
+
+	// Used for the connections, defaults to 30s timeout and keep-alive
+	Dialer *net.Dialer
+
+	// Only the addresses within these ranges are used, if set (pinning)
+	Allow []netip.Prefix
+
+	// The addresses within these ranges are never used
+	Block []netip.Prefix
+
+	// TTL for the answers without TTL, defaults to 5 minutes
+	DefaultTTL time.Duration
+
+	// Bounds for the TTL of the answers (optional), eg: to avoid re-resolving
+	// the hosts with very short TTLs too often
+	MinTTL time.Duration
+	MaxTTL time.Duration
+
+	// TTL for the non-existent hosts (NXDOMAIN), defaults to 1 minute.
+	// Temporary failures are never cached.
+	NegativeTTL time.Duration
+
+	// Per lookup timeout, defaults to 10s
+	Timeout time.Duration
+
+	// Maximum number of cached hosts, defaults to 10000
+	MaxEntries int
+
+	// Refuse the private, loopback, link-local, unspecified and multicast
+	// addresses, eg: to prevent SSRF when crawling user-supplied URLs.
+	BlockPrivate bool
+}
+
+func (c *DNSResolverConfig) validate() error {
+	if c.DefaultTTL < 0 || c.MinTTL < 0 || c.MaxTTL < 0 || c.NegativeTTL < 0 || c.Timeout < 0 || c.MaxEntries < 0 {
+		return errors.New("dns resolver: durations and max entries cannot be negative")
+	}
+	if c.MaxTTL > 0 && c.MinTTL > c.MaxTTL {
+		return errors.New("dns resolver: min TTL cannot exceed max TTL")
+	}
+	if c.Lookup == nil {
+		c.Lookup = SystemLookup{}
+	}
+	if c.Dialer == nil {
+		c.Dialer = &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	}
+	if c.DefaultTTL == 0 {
+		c.DefaultTTL = 5 * time.Minute
+	}
+	if c.NegativeTTL == 0 {
+		c.NegativeTTL = time.Minute
+	}
+	if c.Timeout == 0 {
+		c.Timeout = 10 * time.Second
+	}
+	if c.MaxEntries == 0 {
+		c.MaxEntries = 10000
+	}
+	return nil
+}
+
+type dnsEntry struct {
+	expires time.Time
+	err     error
+	addrs   []netip.Addr
+}
+
+// DNSResolver caches the DNS answers (positive and negative) as per their TTL,
+// and deduplicates the concurrent lookups of the same host.
+//
+// It's plugged into the transport of the underlying client via
+// [DNSResolver.DialContext], which also enforces the allowed and blocked
+// address ranges on every connection, including the IP literals in the URLs
+// and the redirect targets.
+//
+// # Example Usage
+//
+//	resolver, err := http.NewDNSResolver(http.DNSResolverConfig{BlockPrivate: true})
+//
+//	client := &nethttp.Client{
+//		Transport: &nethttp.Transport{DialContext: resolver.DialContext},
+//	}
+//	f, err := http.NewHttpFetcher(client)
+type DNSResolver struct {
+	sf      singleflight.Group
+	entries map[string]*dnsEntry
+	config  DNSResolverConfig
+	mu      sync.RWMutex
+}
+
+func NewDNSResolver(config DNSResolverConfig) (*DNSResolver, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	return &DNSResolver{
+		entries: make(map[string]*dnsEntry),
+		config:  config,
+	}, nil
+}
+
+// Returns the permitted addresses of the host, from the cache if possible.
+func (r *DNSResolver) LookupHost(ctx context.Context, host string) ([]netip.Addr, error) {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+
+	if addr, err := netip.ParseAddr(strings.Trim(host, "[]")); err == nil {
+		return r.filter(host, []netip.Addr{addr})
+	}
+
+	addrs, err := r.lookup(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	return r.filter(host, addrs)
+}
+
+// Dials the permitted addresses of the host in order, until one succeeds.
+// Can be used as [http.Transport.DialContext].
+func (r *DNSResolver) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+
+	addrs, err := r.LookupHost(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	for _, addr := range addrs {
+		if (network == "tcp4" && !addr.Is4()) || (network == "tcp6" && !addr.Is6()) {
+			continue
+		}
+
+		conn, err := r.config.Dialer.DialContext(ctx, network, net.JoinHostPort(addr.String(), port))
+		if err == nil {
+			return conn, nil
+		}
+		errs = append(errs, err)
+
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil, fmt.Errorf("dns resolver: no %s address for %q", network, host)
+	}
+	return nil, errors.Join(errs...)
+}
+
+// Removes the cached answer for the host, or all of them if empty.
+func (r *DNSResolver) Invalidate(host string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if host == "" {
+		clear(r.entries)
+		return
+	}
+	delete(r.entries, strings.ToLower(strings.TrimSuffix(host, ".")))
+}
+
+func (r *DNSResolver) lookup(ctx context.Context, host string) ([]netip.Addr, error) {
+	r.mu.RLock()
+	entry, ok := r.entries[host]
+	r.mu.RUnlock()
+
+	if ok && time.Now().Before(entry.expires) {
+		return entry.addrs, entry.err
+	}
+
+	// Detached from the caller, as the lookup is shared with the other callers
+	ch := r.sf.DoChan(host, func() (any, error) {
+		lookupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), r.config.Timeout)
+		defer cancel()
+
+		addrs, ttl, err := r.config.Lookup.LookupHost(lookupCtx, host)
+		r.store(host, addrs, ttl, err)
+		return addrs, err
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case result := <-ch:
+		if result.Err != nil {
+			return nil, result.Err
+		}
+		return result.Val.([]netip.Addr), nil
+	}
+}
+
+func (r *DNSResolver) store(host string, addrs []netip.Addr, ttl time.Duration, err error) {
+	if err != nil {
+		var dnsErr *net.DNSError
+		if !errors.As(err, &dnsErr) || !dnsErr.IsNotFound {
+			return
+		}
+		ttl = r.config.NegativeTTL
+	} else {
+		if ttl <= 0 {
+			ttl = r.config.DefaultTTL
+		}
+		if ttl < r.config.MinTTL {
+			ttl = r.config.MinTTL
+		}
+		if r.config.MaxTTL > 0 && ttl > r.config.MaxTTL {
+			ttl = r.config.MaxTTL
+		}
+	}
+
+	now := time.Now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.entries) >= r.config.MaxEntries {
+		r.evict(now)
+	}
+	r.entries[host] = &dnsEntry{
+		expires: now.Add(ttl),
+		err:     err,
+		addrs:   addrs,
+	}
+}
+
+// Removes the expired entries, or arbitrary ones if none expired.
+// Must be called with the lock held.
+func (r *DNSResolver) evict(now time.Time) {
+	for host, entry := range r.entries {
+		if !now.Before(entry.expires) {
+			delete(r.entries, host)
+		}
+	}
+	for host := range r.entries {
+		if len(r.entries) < r.config.MaxEntries {
+			break
+		}
+		delete(r.entries, host)
+	}
+}
+
+// Excludes the addresses which are not permitted, fails if none remain.
+func (r *DNSResolver) filter(host string, addrs []netip.Addr) ([]netip.Addr, error) {
+	permitted := make([]netip.Addr, 0, len(addrs))
+	for _, addr := range addrs {
+		if r.permitted(addr.Unmap()) {
+			permitted = append(permitted, addr)
+		}
+	}
+	if len(permitted) == 0 {
+		return nil, fmt.Errorf("%w: %q resolves to %v", ErrAddressBlocked, host, addrs)
+	}
+	return permitted, nil
+}
+
+func (r *DNSResolver) permitted(addr netip.Addr) bool {
+	if r.config.BlockPrivate && isPrivateAddr(addr) {
+		return false
+	}
+	for _, prefix := range r.config.Block {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	if len(r.config.Allow) == 0 {
+		return true
+	}
+	for _, prefix := range r.config.Allow {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// Carrier-grade NAT (RFC 6598) isn't covered by [netip.Addr.IsPrivate]
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+func isPrivateAddr(addr netip.Addr) bool {
+	return addr.IsPrivate() ||
+		addr.IsLoopback() ||
+		addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() ||
+		addr.IsMulticast() ||
+		addr.IsUnspecified() ||
+		sharedAddressSpace.Contains(addr)
+}
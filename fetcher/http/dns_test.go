@@ -0,0 +1,153 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type lookupFunc func(ctx context.Context, host string) ([]netip.Addr, time.Duration, error)
+
+func (fn lookupFunc) LookupHost(ctx context.Context, host string) ([]netip.Addr, time.Duration, error) {
+	return fn(ctx, host)
+}
+
+func TestDNSResolver(t *testing.T) {
+	loopback := netip.MustParseAddr("127.0.0.1")
+
+	var lookups atomic.Int32
+	lookup := lookupFunc(func(_ context.Context, host string) ([]netip.Addr, time.Duration, error) {
+		lookups.Add(1)
+		time.Sleep(10 * time.Millisecond)
+
+		switch host {
+		case "crawl.test":
+			return []netip.Addr{loopback}, time.Hour, nil
+		case "short.test":
+			return []netip.Addr{loopback}, time.Nanosecond, nil
+		default:
+			return nil, 0, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+		}
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	t.Cleanup(server.Close)
+
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	port := u.Port()
+
+	t.Run("caching", func(t *testing.T) {
+		lookups.Store(0)
+		resolver, err := NewDNSResolver(DNSResolverConfig{Lookup: lookup})
+		require.NoError(t, err)
+
+		var wg sync.WaitGroup
+		for range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				addrs, err := resolver.LookupHost(t.Context(), "crawl.test")
+				assert.NoError(t, err)
+				assert.Equal(t, []netip.Addr{loopback}, addrs)
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, int32(1), lookups.Load(), "concurrent lookups must be deduplicated")
+
+		// Negative answers are cached as well
+		for range 2 {
+			_, err = resolver.LookupHost(t.Context(), "missing.test")
+			assert.Error(t, err)
+		}
+		assert.Equal(t, int32(2), lookups.Load())
+
+		resolver.Invalidate("crawl.test")
+		_, err = resolver.LookupHost(t.Context(), "crawl.test")
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), lookups.Load())
+	})
+
+	t.Run("ttl", func(t *testing.T) {
+		lookups.Store(0)
+		resolver, err := NewDNSResolver(DNSResolverConfig{Lookup: lookup})
+		require.NoError(t, err)
+
+		for range 2 {
+			_, err = resolver.LookupHost(t.Context(), "short.test")
+			require.NoError(t, err)
+		}
+		assert.Equal(t, int32(2), lookups.Load())
+
+		resolver, err = NewDNSResolver(DNSResolverConfig{Lookup: lookup, MinTTL: time.Minute})
+		require.NoError(t, err)
+
+		for range 2 {
+			_, err = resolver.LookupHost(t.Context(), "short.test")
+			require.NoError(t, err)
+		}
+		assert.Equal(t, int32(3), lookups.Load())
+	})
+
+	fetch := func(t *testing.T, config DNSResolverConfig, host string) error {
+		t.Helper()
+
+		resolver, err := NewDNSResolver(config)
+		require.NoError(t, err)
+
+		client := &http.Client{Transport: &http.Transport{DialContext: resolver.DialContext}}
+		f, err := NewHttpFetcher(client)
+		require.NoError(t, err)
+
+		resp, err := f.Get(t.Context(), "http://"+net.JoinHostPort(host, port))
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	}
+
+	t.Run("dial", func(t *testing.T) {
+		assert.NoError(t, fetch(t, DNSResolverConfig{Lookup: lookup}, "crawl.test"))
+	})
+
+	t.Run("block private", func(t *testing.T) {
+		config := DNSResolverConfig{Lookup: lookup, BlockPrivate: true}
+		assert.ErrorIs(t, fetch(t, config, "crawl.test"), ErrAddressBlocked)
+		assert.ErrorIs(t, fetch(t, config, "127.0.0.1"), ErrAddressBlocked, "IP literals must be checked")
+	})
+
+	t.Run("pin", func(t *testing.T) {
+		assert.NoError(t, fetch(t, DNSResolverConfig{Lookup: lookup, Allow: []netip.Prefix{netip.MustParsePrefix("127.0.0.0/8")}}, "crawl.test"))
+		assert.ErrorIs(t, fetch(t, DNSResolverConfig{Lookup: lookup, Allow: []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}}, "crawl.test"), ErrAddressBlocked)
+		assert.ErrorIs(t, fetch(t, DNSResolverConfig{Lookup: lookup, Block: []netip.Prefix{netip.MustParsePrefix("127.0.0.1/32")}}, "crawl.test"), ErrAddressBlocked)
+	})
+
+	t.Run("private ranges", func(t *testing.T) {
+		for addr, private := range map[string]bool{
+			"10.1.2.3":        true,
+			"192.168.1.1":     true,
+			"169.254.169.254": true,
+			"100.64.0.1":      true,
+			"::1":             true,
+			"fd00::1":         true,
+			"0.0.0.0":         true,
+			"93.184.216.34":   false,
+			"2606:4700::1111": false,
+		} {
+			assert.Equal(t, private, isPrivateAddr(netip.MustParseAddr(addr)), addr)
+		}
+	})
+}
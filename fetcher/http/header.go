@@ -7,4 +7,6 @@ const (
 	HeaderContentEncoding string = "Content-Encoding"
 	HeaderContentType     string = "Content-Type"
 	HeaderContentLength   string = "Content-Length"
+	HeaderAcceptEncoding  string = "Accept-Encoding"
+	HeaderAuthorization   string = "Authorization"
 )
@@ -9,6 +9,9 @@ import (
 	"io"
 	"net/http"
 	"net/http/cookiejar"
+	"net/url"
+	"strings"
+	"time"
 
 	"golang.org/x/net/publicsuffix"
 )
@@ -16,8 +19,17 @@ import (
 type HttpFetcher struct {
 	httpClient HttpClient
 	// retryController policy.RetryPolicy
-	eventHook EventHooks
-	cookieJar http.CookieJar
+	eventHook      EventHooks
+	cookieJar      http.CookieJar
+	recorder       ExchangeRecorder
+	redirectPolicy *RedirectPolicy
+	proxyPool      *ProxyPool
+	breaker        *CircuitBreaker
+	authenticator  Authenticator
+	profiles       *ProfileSet
+	throttle       *Throttle
+	charset        charsetConfig
+	maxBodySize    int64
 }
 
 // TODO: Add options to override base client settings.
@@ -37,6 +49,10 @@ func NewHttpFetcher(httpClient HttpClient, opts ...HttpFetcherOptions) (*HttpFet
 		opt(fetcher)
 	}
 
+	if err := fetcher.charset.validate(); err != nil {
+		return nil, fmt.Errorf("http-fetcher: %w", err)
+	}
+
 	return fetcher, nil
 }
 
@@ -81,27 +97,47 @@ func (f *HttpFetcher) do(ctx context.Context, method string, url string, body io
 		opt(req)
 	}
 
-	return f._do(ctx, req)
+	return f._do(ctx, req, nil)
 }
 
-func (f *HttpFetcher) _do(_ context.Context, req *http.Request) (*http.Response, error) {
-	for _, cookie := range f.cookieJar.Cookies(req.URL) {
-		req.AddCookie(cookie)
+// Collects the details of the response body lost during the transformations, optional.
+type bodyStats struct {
+	contentEncoding []string
+	decodedBytes    int64
+}
+
+func (f *HttpFetcher) _do(ctx context.Context, req *http.Request, stats *bodyStats) (*http.Response, error) {
+	// Once per fetch, the redirect hops inherit the headers
+	if f.profiles != nil {
+		f.profiles.apply(req)
 	}
 
-	f.eventHook.OnRequest(req)
+	// Advertise the codings handled by the fetcher, this also prevents
+	// the transparent (gzip only) decompression of [http.Transport].
+	if req.Header.Get(HeaderAcceptEncoding) == "" {
+		req.Header.Set(HeaderAcceptEncoding, acceptEncoding)
+	}
 
-	resp, err := f.httpClient.Do(req)
+	var header http.Header
+	if f.redirectPolicy != nil {
+		header = req.Header.Clone()
+	}
+
+	resp, err := f.send(req, req.URL)
 	if err != nil {
-		f.eventHook.OnError(req, err)
 		return nil, err
 	}
 
-	if cookies := resp.Cookies(); len(cookies) > 0 {
-		f.cookieJar.SetCookies(req.URL, cookies)
+	if f.redirectPolicy != nil {
+		resp, err = f.followRedirects(ctx, resp, header)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	f.eventHook.OnResponse(resp)
+	if stats != nil && hasBody(resp) {
+		stats.contentEncoding = parseContentEncoding(resp.Header.Values(HeaderContentEncoding))
+	}
 
 	// TODO: As per config (set by user), but do it without conditional checks every time
 	if err := decompressResponse(resp); err != nil {
@@ -111,7 +147,11 @@ func (f *HttpFetcher) _do(_ context.Context, req *http.Request) (*http.Response,
 		return nil, fmt.Errorf("decompression failed: %w", err)
 	}
 
-	utf8reader, err := newUTF8WithFallbackReader(resp, "")
+	if stats != nil {
+		resp.Body = &countingReadCloser{ReadCloser: resp.Body, n: &stats.decodedBytes}
+	}
+
+	utf8reader, err := newUTF8WithFallbackReader(resp, &f.charset)
 	if err != nil {
 		if err := resp.Body.Close(); err != nil {
 			return nil, fmt.Errorf("failed to close response body after utf-8 reader error: %w", err)
@@ -122,3 +162,155 @@ func (f *HttpFetcher) _do(_ context.Context, req *http.Request) (*http.Response,
 
 	return resp, nil
 }
+
+// Authenticates the request (if configured) and sends it, retrying once
+// with renewed credentials if they were rejected by the server. The `origin`
+// is the URL of the initial request, the redirect hops share it.
+func (f *HttpFetcher) send(req *http.Request, origin *url.URL) (*http.Response, error) {
+	if f.authenticator == nil || !f.authenticates(req.URL, origin) {
+		return f.exchange(req)
+	}
+
+	if err := f.authenticator.Authenticate(req); err != nil {
+		err = fmt.Errorf("authentication failed: %w", err)
+		f.eventHook.OnError(req, err)
+		return nil, err
+	}
+
+	// Snapshot prior to the cookies being added
+	retry := req.Clone(req.Context())
+
+	resp, err := f.exchange(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	// The body can't be replayed
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return resp, nil
+	}
+
+	refreshed, err := f.authenticator.Refresh(req)
+	if err != nil {
+		f.eventHook.OnError(req, fmt.Errorf("authentication refresh failed: %w", err))
+		return resp, nil
+	}
+	if !refreshed {
+		return resp, nil
+	}
+
+	if req.GetBody != nil {
+		if retry.Body, err = req.GetBody(); err != nil {
+			return resp, nil
+		}
+	}
+	if err := f.authenticator.Authenticate(retry); err != nil {
+		return resp, nil
+	}
+
+	discardBody(resp)
+	return f.exchange(retry)
+}
+
+// The credentials not scoped via [HostAuthenticator] belong to the host of the
+// initial request, they're never sent to the other hosts of the redirect chain.
+func (f *HttpFetcher) authenticates(target, origin *url.URL) bool {
+	if _, scoped := f.authenticator.(HostAuthenticator); scoped {
+		return true
+	}
+	return strings.EqualFold(target.Hostname(), origin.Hostname())
+}
+
+// Performs a single exchange with the underlying client.
+func (f *HttpFetcher) exchange(req *http.Request) (*http.Response, error) {
+	for _, cookie := range f.cookieJar.Cookies(req.URL) {
+		req.AddCookie(cookie)
+	}
+
+	if f.breaker != nil {
+		transition, err := f.breaker.allow(req.URL.Host)
+		f.notifyCircuit(transition)
+		if err != nil {
+			f.eventHook.OnError(req, err)
+			return nil, err
+		}
+	}
+
+	var proxy *url.URL
+	if f.proxyPool != nil {
+		selected, err := f.proxyPool.Select(req.URL)
+		if err != nil {
+			if f.breaker != nil {
+				f.breaker.release(req.URL.Host)
+			}
+			f.eventHook.OnError(req, err)
+			return nil, err
+		}
+		proxy = selected
+		req = withProxy(req, proxy)
+	}
+
+	req, tracer := withTracer(req)
+
+	f.eventHook.OnRequest(req)
+
+	resp, err := f.httpClient.Do(req)
+	if proxy != nil {
+		f.proxyPool.report(req, proxy, resp, err)
+	}
+	if f.breaker != nil {
+		f.notifyCircuit(f.breaker.record(req.URL.Host, resp, err))
+	}
+	if err != nil {
+		if trace, ok := tracer.finish(0); ok {
+			f.notifyTrace(req, trace)
+		}
+		f.eventHook.OnError(req, err)
+		return nil, err
+	}
+
+	if resp.Request == nil {
+		resp.Request = req
+	}
+
+	tracer.gotResponse(resp)
+
+	if f.throttle != nil {
+		resp.Body = newThrottledBody(req, resp, f.throttle, func(throttled time.Duration) {
+			if f.eventHook.OnThrottle != nil {
+				f.eventHook.OnThrottle(req, throttled)
+			}
+		})
+	}
+
+	resp.Body = &tracingBody{
+		body:   resp.Body,
+		tracer: tracer,
+		onDone: func(trace *Trace) { f.notifyTrace(req, trace) },
+	}
+
+	if cookies := resp.Cookies(); len(cookies) > 0 {
+		f.cookieJar.SetCookies(req.URL, cookies)
+	}
+
+	f.eventHook.OnResponse(resp)
+
+	if f.recorder != nil {
+		resp.Body = newRecordingBody(f.recorder, req, resp, tracer.trace.Start, f.maxBodySize)
+	}
+
+	return resp, nil
+}
+
+func (f *HttpFetcher) notifyCircuit(transition *circuitTransition) {
+	if transition == nil || f.eventHook.OnCircuitStateChange == nil {
+		return
+	}
+	f.eventHook.OnCircuitStateChange(transition.host, transition.from, transition.to)
+}
+
+func (f *HttpFetcher) notifyTrace(req *http.Request, trace *Trace) {
+	if f.eventHook.OnTrace != nil {
+		f.eventHook.OnTrace(req, trace)
+	}
+}
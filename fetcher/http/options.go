@@ -3,7 +3,10 @@
 
 package http
 
-import "net/http"
+import (
+	"net/http"
+	"strings"
+)
 
 // Configures the [HttpFetcher] instance
 type HttpFetcherOptions func(*HttpFetcher)
@@ -20,6 +23,110 @@ func WithCookieJar(jar http.CookieJar) HttpFetcherOptions {
 	}
 }
 
+// Hands over every exchange to the recorder, with the response body as received
+// on the wire. The body is buffered in memory until it's closed by the caller.
+func WithExchangeRecorder(recorder ExchangeRecorder) HttpFetcherOptions {
+	return func(f *HttpFetcher) {
+		f.recorder = recorder
+	}
+}
+
+// Follows redirects within the fetcher as per the policy, instead of relying on
+// the underlying client, so that every hop is subject to the policy and is
+// visible to the hooks, cookie jar and recorder. The chain can be retrieved
+// from the response via [RedirectChain].
+//
+// The underlying client must not follow redirects itself, for [http.Client]:
+//
+//	client := &http.Client{
+//		CheckRedirect: func(*http.Request, []*http.Request) error {
+//			return http.ErrUseLastResponse
+//		},
+//	}
+func WithRedirectPolicy(policy RedirectPolicy) HttpFetcherOptions {
+	return func(f *HttpFetcher) {
+		f.redirectPolicy = &policy
+	}
+}
+
+// Routes each request through a proxy chosen from the pool, the outcome of the
+// request is reported back to the pool for health tracking.
+func WithProxyPool(pool *ProxyPool) HttpFetcherOptions {
+	return func(f *HttpFetcher) {
+		f.proxyPool = pool
+	}
+}
+
+// Rejects the requests to failing hosts with [CircuitOpenError], the state
+// transitions are reported via [EventHooks.OnCircuitStateChange].
+func WithCircuitBreaker(breaker *CircuitBreaker) HttpFetcherOptions {
+	return func(f *HttpFetcher) {
+		f.breaker = breaker
+	}
+}
+
+// Sets the credentials on every request (including the redirect hops within
+// the same host), the request is retried once with renewed credentials on
+// `401 Unauthorized`. The credentials are never sent to the other hosts of the
+// redirect chain, use [HostAuthenticator] to scope them to specific hosts.
+func WithAuthenticator(auth Authenticator) HttpFetcherOptions {
+	return func(f *HttpFetcher) {
+		f.authenticator = auth
+	}
+}
+
+// Sets the headers of the profile chosen from the set on every request,
+// unless the request already specifies them.
+func WithHeaderProfiles(profiles *ProfileSet) HttpFetcherOptions {
+	return func(f *HttpFetcher) {
+		f.profiles = profiles
+	}
+}
+
+// Limits the bandwidth of the response bodies, the throttled time is reported
+// via [EventHooks.OnThrottle]. The throttle can be shared across fetchers.
+func WithThrottle(throttle *Throttle) HttpFetcherOptions {
+	return func(f *HttpFetcher) {
+		f.throttle = throttle
+	}
+}
+
+// Limits the body read by [HttpFetcher.Fetch], the bytes beyond the limit are
+// discarded and the result is marked as truncated. The body recorded by the
+// [ExchangeRecorder] is limited likewise. Unlimited by default.
+func WithMaxBodySize(n int64) HttpFetcherOptions {
+	return func(f *HttpFetcher) {
+		f.maxBodySize = n
+	}
+}
+
+// Charset assumed for the textual responses without declared charset
+// (BOM, header or meta tag), instead of heuristic-based detection.
+func WithDefaultEncoding(encoding string) HttpFetcherOptions {
+	return func(f *HttpFetcher) {
+		f.charset.defaultEncoding = encoding
+	}
+}
+
+// Forces the charset for the textual responses from the host (without port),
+// regardless of the declared one. (eg: for sites with misconfigured headers)
+func WithHostCharset(host, encoding string) HttpFetcherOptions {
+	return func(f *HttpFetcher) {
+		if f.charset.hostCharsets == nil {
+			f.charset.hostCharsets = make(map[string]string)
+		}
+		f.charset.hostCharsets[strings.ToLower(host)] = encoding
+	}
+}
+
+// Disables the charset detection and UTF-8 conversion, the (decompressed)
+// body is returned as is. (eg: for binary pipelines)
+func WithRawBody() HttpFetcherOptions {
+	return func(f *HttpFetcher) {
+		f.charset.raw = true
+	}
+}
+
 // Configures individual HTTP Requests made by [HttpFetcher]
 type RequestOptions func(*http.Request)
 
@@ -29,6 +136,12 @@ func WithBasicAuth(username, password string) RequestOptions {
 	}
 }
 
+func WithBearerToken(token string) RequestOptions {
+	return func(req *http.Request) {
+		setBearer(req, token)
+	}
+}
+
 func WithCookies(cookies []*http.Cookie) RequestOptions {
 	return func(req *http.Request) {
 		for _, cookie := range cookies {
@@ -0,0 +1,149 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync/atomic"
+)
+
+// HeaderProfile is a consistent set of request headers (eg: of a specific
+// browser), so that the `User-Agent` never contradicts the other headers.
+type HeaderProfile struct {
+	// Additional headers (eg: `Accept`, `Accept-Language`)
+	Header http.Header
+
+	UserAgent string
+
+	// Product token matched against the `User-agent` lines of robots.txt,
+	// defaults to the first product of [HeaderProfile.UserAgent]
+	// (eg: "synapse" for "synapse/1.0 (+https://example.com/bot)")
+	RobotsToken string
+}
+
+// Token for the robots.txt rule lookup.
+func (p *HeaderProfile) Token() string {
+	if p.RobotsToken != "" {
+		return p.RobotsToken
+	}
+	product, _, _ := strings.Cut(strings.TrimSpace(p.UserAgent), " ")
+	token, _, _ := strings.Cut(product, "/")
+	return token
+}
+
+type ProfileRotation int
+
+const (
+	// Pin each host to a profile, so that the host observes a single client
+	ProfileStickyHost ProfileRotation = iota
+
+	// Cycle through the profiles on every request
+	ProfilePerRequest
+)
+
+// ProfileSet chooses the [HeaderProfile] for the requests.
+//
+// The sticky assignment is derived from the host (without port) alone, so it's
+// stable across restarts and crawler processes sharing the same profiles.
+//
+// # Example Usage
+//
+//	profiles, err := http.NewProfileSet(http.ProfileStickyHost, chrome, firefox)
+//
+//	f, err := http.NewHttpFetcher(client, http.WithHeaderProfiles(profiles))
+//
+//	// Rules of robots.txt are looked up for the token of the host's profile
+//	resolver, err := robots.NewRobotsResolver(robots.RobotsConfig{
+//		UserAgent:    "synapse",
+//		UserAgentFor: profiles.RobotsToken,
+//		TTL:          time.Hour,
+//	}, robotsFetcher, cache)
+type ProfileSet struct {
+	profiles []HeaderProfile
+	next     atomic.Uint64
+	rotation ProfileRotation
+}
+
+// The robots tokens of the profiles must be identical for [ProfilePerRequest],
+// as a host would otherwise observe the crawler under different identities.
+func NewProfileSet(rotation ProfileRotation, profiles ...HeaderProfile) (*ProfileSet, error) {
+	if len(profiles) == 0 {
+		return nil, errors.New("profile set: at least one profile is required")
+	}
+
+	for i := range profiles {
+		if profiles[i].UserAgent == "" {
+			return nil, fmt.Errorf("profile set: user-agent is required (profile: %d)", i)
+		}
+		if rotation == ProfilePerRequest && profiles[i].Token() != profiles[0].Token() {
+			return nil, fmt.Errorf("profile set: per-request rotation requires a common robots token, got %q and %q",
+				profiles[0].Token(), profiles[i].Token())
+		}
+	}
+
+	return &ProfileSet{
+		profiles: profiles,
+		rotation: rotation,
+	}, nil
+}
+
+// Chooses the profile for the request to the host.
+func (s *ProfileSet) Select(host string) *HeaderProfile {
+	count := uint64(len(s.profiles))
+
+	if s.rotation == ProfilePerRequest {
+		return &s.profiles[(s.next.Add(1)-1)%count]
+	}
+
+	return &s.profiles[s.stickyIndex(host)]
+}
+
+// Token of the profile used for the origin (eg: "https://example.com" or
+// "example.com:8080"), for the robots.txt rule lookup.
+func (s *ProfileSet) RobotsToken(origin string) string {
+	if s.rotation == ProfilePerRequest {
+		return s.profiles[0].Token()
+	}
+	return s.profiles[s.stickyIndex(originHost(origin))].Token()
+}
+
+func (s *ProfileSet) stickyIndex(host string) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(strings.ToLower(host)))
+	return int(h.Sum32() % uint32(len(s.profiles))) // #nosec G115 -- non-zero number of profiles
+}
+
+// Sets the headers of the profile, unless already set on the request
+// (eg: via [WithUserAgent] or [WithHeaders]).
+func (s *ProfileSet) apply(req *http.Request) {
+	profile := s.Select(req.URL.Hostname())
+
+	if req.Header.Get("User-Agent") == "" {
+		req.Header.Set("User-Agent", profile.UserAgent)
+	}
+	for key, values := range profile.Header {
+		if len(req.Header.Values(key)) == 0 {
+			req.Header[http.CanonicalHeaderKey(key)] = slices.Clone(values)
+		}
+	}
+}
+
+func originHost(origin string) string {
+	if strings.Contains(origin, "://") {
+		if u, err := url.Parse(origin); err == nil {
+			return u.Hostname()
+		}
+	}
+	if host, _, err := net.SplitHostPort(origin); err == nil {
+		return host
+	}
+	return origin
+}
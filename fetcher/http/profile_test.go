@@ -0,0 +1,93 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeaderProfiles(t *testing.T) {
+	chrome := HeaderProfile{
+		UserAgent: "Mozilla/5.0 (X11; Linux x86_64) Chrome/130.0",
+		Header: http.Header{
+			"Accept":          {"text/html,application/xhtml+xml"},
+			"Accept-Language": {"en-US,en;q=0.9"},
+		},
+	}
+	bot := HeaderProfile{
+		UserAgent: "synapse/1.0 (+https://example.com/bot)",
+		Header:    http.Header{"Accept": {"*/*"}},
+	}
+
+	var received []http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r.Header.Clone())
+	}))
+	t.Cleanup(server.Close)
+
+	get := func(t *testing.T, f *HttpFetcher, opts ...RequestOptions) http.Header {
+		t.Helper()
+		resp, err := f.Get(t.Context(), server.URL, opts...)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		return received[len(received)-1]
+	}
+
+	t.Run("sticky host", func(t *testing.T) {
+		profiles, err := NewProfileSet(ProfileStickyHost, chrome, bot)
+		require.NoError(t, err)
+
+		f, err := NewHttpFetcher(server.Client(), WithHeaderProfiles(profiles))
+		require.NoError(t, err)
+
+		first := get(t, f)
+		second := get(t, f)
+		assert.Equal(t, first.Get("User-Agent"), second.Get("User-Agent"))
+
+		profile := profiles.Select("127.0.0.1")
+		assert.Equal(t, profile.UserAgent, first.Get("User-Agent"))
+		assert.Equal(t, profile.Header.Get("Accept"), first.Get("Accept"))
+		assert.Equal(t, profile.Token(), profiles.RobotsToken(server.URL))
+	})
+
+	t.Run("request overrides", func(t *testing.T) {
+		profiles, err := NewProfileSet(ProfileStickyHost, chrome)
+		require.NoError(t, err)
+
+		f, err := NewHttpFetcher(server.Client(), WithHeaderProfiles(profiles))
+		require.NoError(t, err)
+
+		header := get(t, f, WithUserAgent("custom/2.0"), WithHeaders(map[string]string{"Accept": "application/json"}))
+		assert.Equal(t, "custom/2.0", header.Get("User-Agent"))
+		assert.Equal(t, "application/json", header.Get("Accept"))
+		assert.Equal(t, "en-US,en;q=0.9", header.Get("Accept-Language"))
+	})
+
+	t.Run("per request", func(t *testing.T) {
+		firefox := HeaderProfile{UserAgent: "Mozilla/5.0 (X11; Linux x86_64; rv:131.0) Firefox/131.0"}
+
+		profiles, err := NewProfileSet(ProfilePerRequest, chrome, firefox)
+		require.NoError(t, err)
+
+		f, err := NewHttpFetcher(server.Client(), WithHeaderProfiles(profiles))
+		require.NoError(t, err)
+
+		assert.Equal(t, chrome.UserAgent, get(t, f).Get("User-Agent"))
+		assert.Equal(t, firefox.UserAgent, get(t, f).Get("User-Agent"))
+		assert.Equal(t, "Mozilla", profiles.RobotsToken("example.com"))
+
+		_, err = NewProfileSet(ProfilePerRequest, chrome, bot)
+		assert.Error(t, err, "robots tokens must be identical")
+	})
+
+	t.Run("robots token", func(t *testing.T) {
+		assert.Equal(t, "synapse", bot.Token())
+		assert.Equal(t, "crawler", (&HeaderProfile{UserAgent: "Mozilla/5.0", RobotsToken: "crawler"}).Token())
+	})
+}
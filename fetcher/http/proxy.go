@@ -0,0 +1,275 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+	"time"
+)
+
+var ErrNoHealthyProxy = errors.New("proxy pool: no healthy proxy available")
+
+type ProxySelection int
+
+const (
+	// Cycle through the healthy proxies
+	ProxyRoundRobin ProxySelection = iota
+
+	// Pin each host to a proxy, as long as it's healthy
+	ProxyStickyHost
+
+	// Prefer the healthy proxy with the fewest failures so far
+	ProxyLeastFailures
+)
+
+type ProxyPoolConfig struct {
+	// Proxy URLs, supported schemes: http, https, socks5 and socks5h.
+	Proxies []string
+
+	// Response status codes considered as the proxy being banned, defaults to 403, 407 and 429.
+	BanStatusCodes []int
+
+	// Duration for which an unhealthy proxy is excluded, before it's re-tested
+	// with a single trial request, defaults to 1 minute.
+	Cooldown time.Duration
+
+	// Consecutive failures after which the proxy is marked unhealthy, defaults to 3.
+	MaxFailures int
+
+	Selection ProxySelection
+}
+
+func (c *ProxyPoolConfig) validate() error {
+	if len(c.Proxies) == 0 {
+		return errors.New("proxy pool: at least one proxy is required")
+	}
+	if c.Cooldown < 0 || c.MaxFailures < 0 {
+		return errors.New("proxy pool: cooldown and max failures cannot be negative")
+	}
+	if c.Cooldown == 0 {
+		c.Cooldown = time.Minute
+	}
+	if c.MaxFailures == 0 {
+		c.MaxFailures = 3
+	}
+	if c.BanStatusCodes == nil {
+		c.BanStatusCodes = []int{http.StatusForbidden, http.StatusProxyAuthRequired, http.StatusTooManyRequests}
+	}
+	return nil
+}
+
+type proxyState struct {
+	url            *url.URL
+	unhealthyUntil time.Time
+	failures       int
+	totalFailures  int
+	probing        bool
+}
+
+// Proxy (at the moment) is healthy, or is due to be re-tested.
+func (p *proxyState) available(now time.Time) bool {
+	if p.unhealthyUntil.IsZero() {
+		return true
+	}
+	return !p.probing && !now.Before(p.unhealthyUntil)
+}
+
+type proxyContextKey struct{}
+
+// ProxyPool chooses a forward proxy for each request made by [HttpFetcher]
+// and tracks the health of the proxies, based on the outcome of the requests.
+//
+// The chosen proxy is attached to the request context, the transport of the
+// underlying client must be configured to use it via [ProxyPool.Proxy]:
+//
+//	pool, err := http.NewProxyPool(http.ProxyPoolConfig{
+//		Proxies:   []string{"http://10.0.0.1:3128", "socks5://10.0.0.2:1080"},
+//		Selection: http.ProxyStickyHost,
+//	})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//
+//	client := &nethttp.Client{Transport: &nethttp.Transport{Proxy: pool.Proxy}}
+//	f, err := http.NewHttpFetcher(client, http.WithProxyPool(pool))
+type ProxyPool struct {
+	proxies []*proxyState
+	config  ProxyPoolConfig
+	next    int
+	mu      sync.Mutex
+}
+
+func NewProxyPool(config ProxyPoolConfig) (*ProxyPool, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	proxies := make([]*proxyState, 0, len(config.Proxies))
+	for _, raw := range config.Proxies {
+		u, err := url.Parse(raw)
+		if err != nil {
+			return nil, fmt.Errorf("proxy pool: invalid proxy %q: %w", raw, err)
+		}
+
+		switch u.Scheme {
+		case "http", "https", "socks5", "socks5h":
+		default:
+			return nil, fmt.Errorf("proxy pool: unsupported proxy scheme %q", u.Scheme)
+		}
+
+		proxies = append(proxies, &proxyState{url: u})
+	}
+
+	return &ProxyPool{
+		proxies: proxies,
+		config:  config,
+	}, nil
+}
+
+// Chooses a proxy for the target as per the selection strategy. The outcome
+// of the request must be reported via [ProxyPool.ReportSuccess] or
+// [ProxyPool.ReportFailure], as the chosen proxy may be under re-test.
+func (p *ProxyPool) Select(target *url.URL) (*url.URL, error) {
+	return p.choose(target, true)
+}
+
+// Chooses a proxy, the unhealthy proxy due to be re-tested is reserved for
+// this request if probe is set, i.e. the outcome will be reported.
+func (p *ProxyPool) choose(target *url.URL, probe bool) (*url.URL, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	count := len(p.proxies)
+
+	var chosen *proxyState
+
+	switch p.config.Selection {
+	case ProxyStickyHost:
+		h := fnv.New32a()
+		_, _ = h.Write([]byte(target.Hostname()))
+		start := int(h.Sum32() % uint32(count)) // #nosec G115 -- count is the (non-zero) number of proxies
+		for i := range count {
+			if proxy := p.proxies[(start+i)%count]; proxy.available(now) {
+				chosen = proxy
+				break
+			}
+		}
+
+	case ProxyLeastFailures:
+		for i := range count {
+			proxy := p.proxies[(p.next+i)%count]
+			if proxy.available(now) && (chosen == nil || proxy.totalFailures < chosen.totalFailures) {
+				chosen = proxy
+			}
+		}
+		p.next = (p.next + 1) % count
+
+	default:
+		for i := range count {
+			idx := (p.next + i) % count
+			if proxy := p.proxies[idx]; proxy.available(now) {
+				chosen = proxy
+				p.next = (idx + 1) % count
+				break
+			}
+		}
+	}
+
+	if chosen == nil {
+		return nil, ErrNoHealthyProxy
+	}
+
+	if probe && !chosen.unhealthyUntil.IsZero() {
+		// Cooldown elapsed, only this request re-tests the proxy
+		chosen.probing = true
+	}
+
+	return chosen.url, nil
+}
+
+// Records a successful request, restoring the proxy health.
+func (p *ProxyPool) ReportSuccess(proxy *url.URL) {
+	p.update(proxy, func(state *proxyState) {
+		state.failures = 0
+		state.probing = false
+		state.unhealthyUntil = time.Time{}
+	})
+}
+
+// Records a failed request (eg: connection error or ban), the proxy is marked
+// unhealthy for the cooldown duration once the failures exceed the threshold.
+func (p *ProxyPool) ReportFailure(proxy *url.URL) {
+	p.update(proxy, func(state *proxyState) {
+		state.failures++
+		state.totalFailures++
+
+		if state.probing || state.failures >= p.config.MaxFailures {
+			state.unhealthyUntil = time.Now().Add(p.config.Cooldown)
+		}
+		state.probing = false
+	})
+}
+
+// Reports whether the proxy is currently healthy.
+func (p *ProxyPool) Healthy(proxy *url.URL) bool {
+	healthy := false
+	p.update(proxy, func(state *proxyState) {
+		healthy = state.unhealthyUntil.IsZero()
+	})
+	return healthy
+}
+
+// Proxy function for [http.Transport.Proxy], it returns the proxy chosen by the
+// fetcher for the request, or chooses one if the request wasn't made via fetcher.
+// The outcome of the latter isn't reported, so the proxy isn't reserved for the re-test.
+func (p *ProxyPool) Proxy(req *http.Request) (*url.URL, error) {
+	if proxy, ok := req.Context().Value(proxyContextKey{}).(*url.URL); ok {
+		return proxy, nil
+	}
+	return p.choose(req.URL, false)
+}
+
+// Reports the outcome of the exchange of the request made through the proxy.
+func (p *ProxyPool) report(req *http.Request, proxy *url.URL, resp *http.Response, err error) {
+	switch {
+	case err != nil:
+		// Cancelled by the caller, not attributable to the proxy, unlike the
+		// timeouts (eg: of the dial, or of the client) which are counted.
+		if errors.Is(req.Context().Err(), context.Canceled) {
+			p.update(proxy, func(state *proxyState) { state.probing = false })
+			return
+		}
+		p.ReportFailure(proxy)
+
+	case slices.Contains(p.config.BanStatusCodes, resp.StatusCode):
+		p.ReportFailure(proxy)
+
+	default:
+		p.ReportSuccess(proxy)
+	}
+}
+
+func (p *ProxyPool) update(proxy *url.URL, fn func(*proxyState)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, state := range p.proxies {
+		if state.url == proxy || state.url.String() == proxy.String() {
+			fn(state)
+			return
+		}
+	}
+}
+
+func withProxy(req *http.Request, proxy *url.URL) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), proxyContextKey{}, proxy))
+}
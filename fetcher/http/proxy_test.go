@@ -0,0 +1,331 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Stand-in forward proxy, it answers the proxied requests itself.
+func newStandInProxy(t *testing.T, name string, status int) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !r.URL.IsAbs() {
+			http.Error(w, "not a proxy request", http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.WriteHeader(status)
+		_, _ = io.WriteString(w, name)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// Minimal SOCKS5 proxy (RFC 1928), without authentication and CONNECT only.
+// Returns the address and the number of tunnels made so far.
+func newSOCKS5Proxy(t *testing.T) (string, *atomic.Int32) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	var tunnels atomic.Int32
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer func() { _ = conn.Close() }()
+				if target, ok := socks5Handshake(conn); ok {
+					tunnels.Add(1)
+					defer func() { _ = target.Close() }()
+					go func() { _, _ = io.Copy(target, conn) }()
+					_, _ = io.Copy(conn, target)
+				}
+			}()
+		}
+	}()
+
+	return listener.Addr().String(), &tunnels
+}
+
+func socks5Handshake(conn net.Conn) (net.Conn, bool) {
+	// Version, methods
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(conn, header); err != nil || header[0] != 5 {
+		return nil, false
+	}
+	if _, err := io.ReadFull(conn, make([]byte, header[1])); err != nil {
+		return nil, false
+	}
+	if _, err := conn.Write([]byte{5, 0}); err != nil {
+		return nil, false
+	}
+
+	// Version, command, reserved, address type
+	request := make([]byte, 4)
+	if _, err := io.ReadFull(conn, request); err != nil || request[1] != 1 {
+		return nil, false
+	}
+
+	var host string
+	switch request[3] {
+	case 1:
+		ip := make([]byte, net.IPv4len)
+		if _, err := io.ReadFull(conn, ip); err != nil {
+			return nil, false
+		}
+		host = net.IP(ip).String()
+	case 3:
+		length := make([]byte, 1)
+		if _, err := io.ReadFull(conn, length); err != nil {
+			return nil, false
+		}
+		domain := make([]byte, length[0])
+		if _, err := io.ReadFull(conn, domain); err != nil {
+			return nil, false
+		}
+		host = string(domain)
+	default:
+		return nil, false
+	}
+
+	port := make([]byte, 2)
+	if _, err := io.ReadFull(conn, port); err != nil {
+		return nil, false
+	}
+
+	target, err := net.Dial("tcp", net.JoinHostPort(host, strconv.Itoa(int(binary.BigEndian.Uint16(port)))))
+	if err != nil {
+		_, _ = conn.Write([]byte{5, 5, 0, 1, 0, 0, 0, 0, 0, 0})
+		return nil, false
+	}
+	if _, err := conn.Write([]byte{5, 0, 0, 1, 0, 0, 0, 0, 0, 0}); err != nil {
+		_ = target.Close()
+		return nil, false
+	}
+	return target, true
+}
+
+func fetchVia(t *testing.T, f *HttpFetcher, target string) string {
+	t.Helper()
+
+	resp, err := f.Get(t.Context(), target)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestProxyPool(t *testing.T) {
+	good := newStandInProxy(t, "good", http.StatusOK)
+	banned := newStandInProxy(t, "banned", http.StatusTooManyRequests)
+
+	newFetcher := func(t *testing.T, config ProxyPoolConfig) (*HttpFetcher, *ProxyPool) {
+		t.Helper()
+
+		pool, err := NewProxyPool(config)
+		require.NoError(t, err)
+
+		client := &http.Client{Transport: &http.Transport{Proxy: pool.Proxy}}
+		f, err := NewHttpFetcher(client, WithProxyPool(pool))
+		require.NoError(t, err)
+
+		return f, pool
+	}
+
+	t.Run("round robin skips unhealthy proxies", func(t *testing.T) {
+		f, pool := newFetcher(t, ProxyPoolConfig{
+			Proxies:     []string{good.URL, banned.URL},
+			MaxFailures: 1,
+			Cooldown:    time.Hour,
+		})
+
+		assert.Equal(t, "good", fetchVia(t, f, "http://origin.test/1"))
+		assert.Equal(t, "banned", fetchVia(t, f, "http://origin.test/2"))
+
+		bannedURL, err := url.Parse(banned.URL)
+		require.NoError(t, err)
+		assert.False(t, pool.Healthy(bannedURL))
+
+		for range 3 {
+			assert.Equal(t, "good", fetchVia(t, f, "http://origin.test/3"))
+		}
+	})
+
+	t.Run("re-test after cooldown", func(t *testing.T) {
+		f, pool := newFetcher(t, ProxyPoolConfig{
+			Proxies:     []string{banned.URL},
+			MaxFailures: 1,
+			Cooldown:    50 * time.Millisecond,
+		})
+
+		assert.Equal(t, "banned", fetchVia(t, f, "http://origin.test/"))
+
+		_, err := f.Get(t.Context(), "http://origin.test/")
+		assert.ErrorIs(t, err, ErrNoHealthyProxy)
+
+		time.Sleep(60 * time.Millisecond)
+		target, err := url.Parse("http://origin.test/")
+		require.NoError(t, err)
+		proxy, err := pool.Select(target)
+		require.NoError(t, err, "proxy must be re-tested after cooldown")
+
+		_, err = pool.Select(target)
+		assert.ErrorIs(t, err, ErrNoHealthyProxy, "only a single trial request is allowed")
+
+		pool.ReportSuccess(proxy)
+		assert.True(t, pool.Healthy(proxy))
+	})
+
+	t.Run("connection errors", func(t *testing.T) {
+		unreachable := httptest.NewServer(http.NotFoundHandler())
+		unreachable.Close()
+
+		f, _ := newFetcher(t, ProxyPoolConfig{
+			Proxies:     []string{unreachable.URL, good.URL},
+			MaxFailures: 1,
+			Cooldown:    time.Hour,
+		})
+
+		_, err := f.Get(t.Context(), "http://origin.test/")
+		require.Error(t, err)
+
+		for range 3 {
+			assert.Equal(t, "good", fetchVia(t, f, "http://origin.test/"))
+		}
+	})
+
+	t.Run("timeouts", func(t *testing.T) {
+		stalled := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		}))
+		t.Cleanup(stalled.Close)
+
+		proxy, err := url.Parse(stalled.URL)
+		require.NoError(t, err)
+
+		// Cancelled by the caller, not counted
+		f, pool := newFetcher(t, ProxyPoolConfig{Proxies: []string{stalled.URL}, MaxFailures: 1, Cooldown: time.Hour})
+		ctx, cancel := context.WithCancel(t.Context())
+		time.AfterFunc(20*time.Millisecond, cancel)
+		_, err = f.Get(ctx, "http://origin.test/")
+		require.ErrorIs(t, err, context.Canceled)
+		assert.True(t, pool.Healthy(proxy))
+
+		// Timed out by the client, counted
+		pool, err = NewProxyPool(ProxyPoolConfig{Proxies: []string{stalled.URL}, MaxFailures: 1, Cooldown: time.Hour})
+		require.NoError(t, err)
+		client := &http.Client{Transport: &http.Transport{Proxy: pool.Proxy}, Timeout: 20 * time.Millisecond}
+		f, err = NewHttpFetcher(client, WithProxyPool(pool))
+		require.NoError(t, err)
+
+		_, err = f.Get(t.Context(), "http://origin.test/")
+		require.Error(t, err)
+		assert.False(t, pool.Healthy(proxy))
+	})
+
+	t.Run("sticky host", func(t *testing.T) {
+		f, _ := newFetcher(t, ProxyPoolConfig{
+			Proxies:   []string{good.URL, newStandInProxy(t, "other", http.StatusOK).URL},
+			Selection: ProxyStickyHost,
+		})
+
+		first := fetchVia(t, f, "http://origin.test/1")
+		for range 3 {
+			assert.Equal(t, first, fetchVia(t, f, "http://origin.test/2"))
+		}
+	})
+
+	t.Run("least failures", func(t *testing.T) {
+		other := newStandInProxy(t, "other", http.StatusOK)
+		f, pool := newFetcher(t, ProxyPoolConfig{
+			Proxies:   []string{good.URL, other.URL},
+			Selection: ProxyLeastFailures,
+		})
+
+		goodURL, err := url.Parse(good.URL)
+		require.NoError(t, err)
+		otherURL, err := url.Parse(other.URL)
+		require.NoError(t, err)
+
+		pool.ReportFailure(goodURL)
+		for range 3 {
+			assert.Equal(t, "other", fetchVia(t, f, "http://origin.test/"))
+		}
+
+		// The failures are counted in total, regardless of the successes since
+		pool.ReportFailure(otherURL)
+		pool.ReportFailure(otherURL)
+		for range 3 {
+			assert.Equal(t, "good", fetchVia(t, f, "http://origin.test/"))
+		}
+		assert.True(t, pool.Healthy(otherURL))
+	})
+
+	t.Run("socks5", func(t *testing.T) {
+		origin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			_, _ = io.WriteString(w, "origin")
+		}))
+		t.Cleanup(origin.Close)
+
+		addr, tunnels := newSOCKS5Proxy(t)
+		f, pool := newFetcher(t, ProxyPoolConfig{Proxies: []string{"socks5://" + addr}})
+
+		assert.Equal(t, "origin", fetchVia(t, f, origin.URL))
+		assert.Equal(t, int32(1), tunnels.Load())
+
+		proxy, err := url.Parse("socks5://" + addr)
+		require.NoError(t, err)
+		assert.True(t, pool.Healthy(proxy))
+	})
+
+	t.Run("transport fallback", func(t *testing.T) {
+		pool, err := NewProxyPool(ProxyPoolConfig{
+			Proxies:     []string{banned.URL},
+			MaxFailures: 1,
+			Cooldown:    10 * time.Millisecond,
+		})
+		require.NoError(t, err)
+
+		proxy, err := url.Parse(banned.URL)
+		require.NoError(t, err)
+		pool.ReportFailure(proxy)
+		time.Sleep(20 * time.Millisecond)
+
+		// Made without the fetcher, the outcome is never reported
+		req, err := http.NewRequest(http.MethodGet, "http://origin.test/", nil)
+		require.NoError(t, err)
+		for range 3 {
+			chosen, err := pool.Proxy(req)
+			require.NoError(t, err)
+			assert.Equal(t, banned.URL, chosen.String())
+		}
+
+		_, err = pool.Select(req.URL)
+		require.NoError(t, err, "the proxy must still be available for the re-test")
+	})
+}
@@ -0,0 +1,138 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Exchange is a single request/response pair observed by [HttpFetcher].
+//
+// The response header and body are captured as received from the underlying
+// [HttpClient], i.e. before decompression and charset normalization.
+type Exchange struct {
+	// Time at which the request was handed over to the underlying client.
+	Date time.Time
+
+	Request  *http.Request
+	Response *http.Response
+
+	// Response header snapshot, before it is mutated by the fetcher.
+	Header http.Header
+
+	// Raw response body bytes.
+	Body []byte
+
+	// Time elapsed from sending the request until the body was closed.
+	Duration time.Duration
+
+	// Set when the body could not be read completely, or exceeded the
+	// limit of [WithMaxBodySize].
+	Truncated bool
+
+	// Set along with Truncated when the body exceeded the limit of [WithMaxBodySize].
+	TooLarge bool
+}
+
+// ExchangeRecorder receives every exchange made by [HttpFetcher], once the
+// response body is closed by the caller. (eg: WARC archival)
+type ExchangeRecorder interface {
+	Record(exchange *Exchange) error
+}
+
+// Captures the raw response body while it's being read by the caller.
+// On close, the remaining unread bytes are drained (up to the limit) so that
+// the recorded exchange is complete, then the exchange is handed to the recorder.
+type recordingBody struct {
+	body     io.ReadCloser
+	recorder ExchangeRecorder
+	exchange *Exchange
+	buf      bytes.Buffer
+	// Maximum bytes recorded, unlimited if not positive
+	limit     int64
+	eof       bool
+	failed    bool
+	truncated bool
+	closed    bool
+}
+
+func newRecordingBody(recorder ExchangeRecorder, req *http.Request, resp *http.Response, date time.Time, limit int64) *recordingBody {
+	return &recordingBody{
+		body:     resp.Body,
+		recorder: recorder,
+		limit:    limit,
+		exchange: &Exchange{
+			Date:     date,
+			Request:  req,
+			Response: resp,
+			Header:   resp.Header.Clone(),
+		},
+	}
+}
+
+func (r *recordingBody) Read(p []byte) (int, error) {
+	n, err := r.body.Read(p)
+	r.record(p[:n])
+
+	switch {
+	case err == io.EOF:
+		r.eof = true
+	case err != nil:
+		r.failed = true
+	}
+
+	return n, err
+}
+
+func (r *recordingBody) Close() error {
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+
+	if !r.eof && !r.failed && !r.truncated {
+		var remaining io.Reader = r.body
+		if r.limit > 0 {
+			// One more byte than the limit, to detect the truncation
+			remaining = io.LimitReader(r.body, r.limit-int64(r.buf.Len())+1)
+		}
+		if _, err := io.Copy(writerFunc(r.record), remaining); err != nil {
+			r.failed = true
+		}
+	}
+
+	closeErr := r.body.Close()
+
+	r.exchange.Body = r.buf.Bytes()
+	r.exchange.Duration = time.Since(r.exchange.Date)
+	r.exchange.Truncated = r.failed || r.truncated
+	r.exchange.TooLarge = r.truncated
+
+	if err := r.recorder.Record(r.exchange); err != nil {
+		return err
+	}
+
+	return closeErr
+}
+
+// Buffers the bytes up to the limit, the ones beyond it are dropped.
+func (r *recordingBody) record(p []byte) {
+	if r.limit > 0 {
+		if remaining := r.limit - int64(r.buf.Len()); int64(len(p)) > remaining {
+			p = p[:remaining]
+			r.truncated = true
+		}
+	}
+	r.buf.Write(p)
+}
+
+type writerFunc func([]byte)
+
+func (w writerFunc) Write(p []byte) (int, error) {
+	w(p)
+	return len(p), nil
+}
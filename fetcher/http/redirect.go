@@ -0,0 +1,270 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/publicsuffix"
+)
+
+const defaultMaxRedirects = 10
+
+var (
+	ErrTooManyRedirects   = errors.New("http-fetcher: too many redirects")
+	ErrRedirectLoop       = errors.New("http-fetcher: redirect loop")
+	ErrRedirectOutOfScope = errors.New("http-fetcher: redirect out of scope")
+)
+
+type RedirectScope int
+
+const (
+	// Follow redirects to any host
+	RedirectScopeAny RedirectScope = iota
+
+	// Follow redirects only within the same host (eg: http -> https)
+	RedirectScopeSameHost
+
+	// Follow redirects only within the same registrable domain (eTLD+1)
+	RedirectScopeSameDomain
+)
+
+// Determines which redirects are followed by [HttpFetcher].
+type RedirectPolicy struct {
+	// Vetoes individual hops, same semantics as [http.Client.CheckRedirect].
+	// The returned error aborts the fetch, the request is the upcoming hop
+	// and `via` contains the requests made so far, oldest first.
+	CheckRedirect func(req *http.Request, via []*http.Request) error
+
+	// Maximum number of hops to follow, defaults to 10.
+	MaxHops int
+
+	Scope RedirectScope
+}
+
+// Redirect is a single hop of the redirect chain.
+type Redirect struct {
+	// URL that responded with the redirect
+	URL string
+
+	// Resolved target of the `Location` header
+	Location string
+
+	StatusCode int
+}
+
+// Returns the redirects followed to obtain the response, oldest first.
+// The final URL is the one of `resp.Request`.
+//
+// The chain is derived from [http.Request.Response], so it's also available
+// when redirects are followed by the underlying [http.Client].
+func RedirectChain(resp *http.Response) []Redirect {
+	if resp == nil || resp.Request == nil {
+		return nil
+	}
+
+	var chain []Redirect
+	for req := resp.Request; req.Response != nil && req.Response.Request != nil; req = req.Response.Request {
+		chain = append(chain, Redirect{
+			URL:        req.Response.Request.URL.String(),
+			Location:   req.URL.String(),
+			StatusCode: req.Response.StatusCode,
+		})
+	}
+
+	for i, j := 0, len(chain)-1; i < j; i, j = i+1, j-1 {
+		chain[i], chain[j] = chain[j], chain[i]
+	}
+
+	return chain
+}
+
+func isRedirect(statusCode int) bool {
+	switch statusCode {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther,
+		http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		return true
+	default:
+		return false
+	}
+}
+
+// Follows the redirects as per the policy and returns the final response.
+// The `header` is the snapshot of the initial request header, before the
+// cookies from the jar were added.
+func (f *HttpFetcher) followRedirects(ctx context.Context, resp *http.Response, header http.Header) (*http.Response, error) {
+	policy := f.redirectPolicy
+
+	maxHops := policy.MaxHops
+	if maxHops <= 0 {
+		maxHops = defaultMaxRedirects
+	}
+
+	via := []*http.Request{resp.Request}
+	visited := map[string]struct{}{
+		hopKey(resp.Request, resp.Request.Header.Get("Cookie")): {},
+	}
+
+	// Errors from the client are reported by [HttpFetcher.send]
+	fail := func(err error) (*http.Response, error) {
+		f.eventHook.OnError(via[len(via)-1], err)
+		return nil, err
+	}
+
+	for isRedirect(resp.StatusCode) {
+		location := resp.Header.Get("Location")
+		if location == "" {
+			return resp, nil
+		}
+
+		prev := via[len(via)-1]
+		discardBody(resp)
+
+		target, err := prev.URL.Parse(location)
+		if err != nil {
+			return fail(fmt.Errorf("http-fetcher: invalid redirect location %q: %w", location, err))
+		}
+
+		if len(via) > maxHops {
+			return fail(fmt.Errorf("%w: stopped after %d hops at %s", ErrTooManyRedirects, maxHops, target))
+		}
+
+		if !policy.Scope.allows(via[0].URL, target) {
+			return fail(fmt.Errorf("%w: %s -> %s", ErrRedirectOutOfScope, prev.URL, target))
+		}
+
+		hopHeader := header
+		if !strings.EqualFold(via[0].URL.Hostname(), target.Hostname()) {
+			// Credentials are never forwarded to other hosts
+			hopHeader = header.Clone()
+			hopHeader.Del("Authorization")
+			hopHeader.Del("Www-Authenticate")
+			hopHeader.Del("Cookie")
+		}
+
+		next, err := newRedirectRequest(ctx, prev, resp, target, hopHeader)
+		if err != nil {
+			return fail(err)
+		}
+
+		// Revisiting the URL with new cookies (eg: login, consent) isn't a loop
+		key := hopKey(next, f.pendingCookies(next))
+		if _, ok := visited[key]; ok {
+			return fail(fmt.Errorf("%w: %s", ErrRedirectLoop, target))
+		}
+		visited[key] = struct{}{}
+
+		if policy.CheckRedirect != nil {
+			if err := policy.CheckRedirect(next, via); err != nil {
+				return fail(err)
+			}
+		}
+
+		resp, err = f.send(next, via[0].URL)
+		if err != nil {
+			return nil, err
+		}
+		via = append(via, next)
+	}
+
+	return resp, nil
+}
+
+// Identifies the hop by the method, URL and the cookies sent with it.
+func hopKey(req *http.Request, cookie string) string {
+	return req.Method + " " + req.URL.String() + "\n" + cookie
+}
+
+// Cookie header of the request once the cookies from the jar are added,
+// as per [HttpFetcher.exchange].
+func (f *HttpFetcher) pendingCookies(req *http.Request) string {
+	probe := &http.Request{Header: http.Header{"Cookie": req.Header.Values("Cookie")}}
+	for _, cookie := range f.cookieJar.Cookies(req.URL) {
+		probe.AddCookie(cookie)
+	}
+	return probe.Header.Get("Cookie")
+}
+
+func (s RedirectScope) allows(origin, target *url.URL) bool {
+	switch s {
+	case RedirectScopeSameHost:
+		return strings.EqualFold(origin.Hostname(), target.Hostname())
+
+	case RedirectScopeSameDomain:
+		originDomain, err := publicsuffix.EffectiveTLDPlusOne(origin.Hostname())
+		if err != nil {
+			return strings.EqualFold(origin.Hostname(), target.Hostname())
+		}
+		targetDomain, err := publicsuffix.EffectiveTLDPlusOne(target.Hostname())
+		if err != nil {
+			return false
+		}
+		return strings.EqualFold(originDomain, targetDomain)
+
+	default:
+		return true
+	}
+}
+
+// Builds the request for the next hop, similar to [http.Client].
+func newRedirectRequest(ctx context.Context, prev *http.Request, resp *http.Response, target *url.URL, header http.Header) (*http.Request, error) {
+	method := prev.Method
+	var body io.ReadCloser
+
+	switch resp.StatusCode {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther:
+		if method != http.MethodGet && method != http.MethodHead {
+			method = http.MethodGet
+		}
+
+	case http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		if prev.GetBody != nil {
+			b, err := prev.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			body = b
+		} else if prev.Body != nil && prev.Body != http.NoBody {
+			return nil, fmt.Errorf("http-fetcher: cannot replay request body for redirect to %s", target)
+		}
+	}
+
+	next, err := http.NewRequestWithContext(ctx, method, target.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		next.GetBody = prev.GetBody
+		next.ContentLength = prev.ContentLength
+	}
+
+	next.Header = header.Clone()
+
+	if prev.URL.Scheme != "https" || target.Scheme == "https" {
+		next.Header.Set("Referer", prev.URL.String())
+	}
+
+	// Links the hop for [RedirectChain]
+	resp.Request = prev
+	next.Response = resp
+
+	return next, nil
+}
+
+// Drains (bounded) and closes the body of an intermediate response,
+// so that the connection can be reused.
+func discardBody(resp *http.Response) {
+	const maxDiscard = 2 << 10
+	if resp.Body == nil {
+		return
+	}
+	_, _ = io.CopyN(io.Discard, resp.Body, maxDiscard)
+	_ = resp.Body.Close()
+}
@@ -0,0 +1,126 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newNoRedirectClient() *http.Client {
+	return &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+func TestRedirectPolicy(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/a", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/b", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/b", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/final", http.StatusFound)
+	})
+	mux.HandleFunc("/final", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, _ = io.WriteString(w, "done")
+	})
+	mux.HandleFunc("/loop", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/loop", http.StatusFound)
+	})
+	mux.HandleFunc("/account", func(w http.ResponseWriter, r *http.Request) {
+		if _, err := r.Cookie("session"); err != nil {
+			http.Redirect(w, r, "/login", http.StatusFound)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, _ = io.WriteString(w, "account")
+	})
+	mux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc", Path: "/"})
+		http.Redirect(w, r, "/account", http.StatusFound)
+	})
+	mux.HandleFunc("/external", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://example.com/", http.StatusFound)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	t.Run("chain", func(t *testing.T) {
+		f, err := NewHttpFetcher(newNoRedirectClient(), WithRedirectPolicy(RedirectPolicy{}))
+		require.NoError(t, err)
+
+		resp, err := f.Get(t.Context(), server.URL+"/a")
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = resp.Body.Close() })
+
+		assert.Equal(t, server.URL+"/final", resp.Request.URL.String())
+		assert.Equal(t, []Redirect{
+			{URL: server.URL + "/a", Location: server.URL + "/b", StatusCode: http.StatusMovedPermanently},
+			{URL: server.URL + "/b", Location: server.URL + "/final", StatusCode: http.StatusFound},
+		}, RedirectChain(resp))
+	})
+
+	t.Run("max hops", func(t *testing.T) {
+		f, err := NewHttpFetcher(newNoRedirectClient(), WithRedirectPolicy(RedirectPolicy{MaxHops: 1}))
+		require.NoError(t, err)
+
+		_, err = f.Get(t.Context(), server.URL+"/a")
+		assert.ErrorIs(t, err, ErrTooManyRedirects)
+	})
+
+	t.Run("loop", func(t *testing.T) {
+		f, err := NewHttpFetcher(newNoRedirectClient(), WithRedirectPolicy(RedirectPolicy{}))
+		require.NoError(t, err)
+
+		_, err = f.Get(t.Context(), server.URL+"/loop")
+		assert.ErrorIs(t, err, ErrRedirectLoop)
+	})
+
+	t.Run("return with new cookies", func(t *testing.T) {
+		f, err := NewHttpFetcher(newNoRedirectClient(), WithRedirectPolicy(RedirectPolicy{}))
+		require.NoError(t, err)
+
+		resp, err := f.Get(t.Context(), server.URL+"/account")
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = resp.Body.Close() })
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, server.URL+"/account", resp.Request.URL.String())
+		assert.Len(t, RedirectChain(resp), 2)
+	})
+
+	t.Run("scope", func(t *testing.T) {
+		f, err := NewHttpFetcher(newNoRedirectClient(), WithRedirectPolicy(RedirectPolicy{Scope: RedirectScopeSameHost}))
+		require.NoError(t, err)
+
+		_, err = f.Get(t.Context(), server.URL+"/external")
+		assert.ErrorIs(t, err, ErrRedirectOutOfScope)
+	})
+
+	t.Run("veto", func(t *testing.T) {
+		errVetoed := errors.New("vetoed")
+		f, err := NewHttpFetcher(newNoRedirectClient(), WithRedirectPolicy(RedirectPolicy{
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				if req.URL.Path == "/final" {
+					return errVetoed
+				}
+				return nil
+			},
+		}))
+		require.NoError(t, err)
+
+		_, err = f.Get(t.Context(), server.URL+"/a")
+		assert.ErrorIs(t, err, errVetoed)
+	})
+}
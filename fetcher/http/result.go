@@ -0,0 +1,200 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/ritvikos/synapse/fetcher"
+	"github.com/ritvikos/synapse/robots/directives"
+)
+
+// FetchResult is the outcome of [HttpFetcher.Fetch], with the body read
+// completely (decompressed and normalized to UTF-8, for textual content)
+// along with the details that the transformations would otherwise discard.
+//
+// It can be handed over to the pipeline as is, as it exposes the content
+// type and the body reader. (eg: `pipeline.Router.Dispatch`)
+type FetchResult struct {
+	// Time at which the fetch was started
+	FetchedAt time.Time
+
+	// Final URL, after following the redirects (if any)
+	URL *url.URL
+
+	// Response header of the final exchange.
+	// `Content-Encoding` is removed once decoded, see [FetchResult.ContentEncoding].
+	Header http.Header
+
+	// Timings and connection details of the final exchange
+	Trace *Trace
+
+	// Charset of the textual content, nil for non-textual content or raw mode
+	Charset *CharsetMetadata
+
+	// Hops followed by the fetcher, see [WithRedirectPolicy]
+	Redirects []Redirect
+
+	// SHA-256 digest of the body (eg: "sha256:9f86...")
+	Digest string
+
+	// Original content codings (eg: ["gzip"]), in the order they were applied
+	ContentEncoding []string
+
+	Body []byte
+
+	StatusCode int
+
+	// Body size as received on the wire (i.e. possibly compressed)
+	WireBytes int64
+
+	// Body size after decompression, before charset normalization
+	DecodedBytes int64
+
+	// Time elapsed for the whole fetch, including redirects
+	Duration time.Duration
+
+	// Set when the body exceeded the limit, see [WithMaxBodySize]
+	Truncated bool
+}
+
+// Media type of the body, without parameters. (eg: "text/html")
+func (r *FetchResult) ContentType() string {
+	if r.Charset != nil {
+		return r.Charset.MIMEType
+	}
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get(HeaderContentType))
+	if err != nil {
+		return ""
+	}
+	return mediaType
+}
+
+func (r *FetchResult) Reader() io.Reader {
+	return bytes.NewReader(r.Body)
+}
+
+// Page-level directives of the `X-Robots-Tag` headers for the user-agent token,
+// merge with the meta robots of the document for HTML pages.
+func (r *FetchResult) RobotsDirectives(userAgent string) directives.Directives {
+	return directives.ParseXRobotsTag(r.Header.Values(directives.HeaderXRobotsTag), userAgent)
+}
+
+// Fetches the URL via GET and reads the body completely.
+//
+// Unlike [HttpFetcher.Get], the caller doesn't have to close the response,
+// and the original encoding, charset, redirects and timings are retained.
+func (f *HttpFetcher) Fetch(ctx context.Context, url string, opts ...RequestOptions) (*FetchResult, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, opt := range opts {
+		opt(req)
+	}
+
+	start := time.Now()
+
+	var stats bodyStats
+	resp, err := f._do(ctx, req, &stats)
+	if err != nil {
+		return nil, err
+	}
+
+	body, truncated, err := readBody(resp.Body, f.maxBodySize)
+	if closeErr := resp.Body.Close(); err == nil && closeErr != nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	digest := sha256.Sum256(body)
+
+	result := &FetchResult{
+		FetchedAt:       start,
+		URL:             resp.Request.URL,
+		Header:          resp.Header,
+		Redirects:       RedirectChain(resp),
+		ContentEncoding: stats.contentEncoding,
+		Body:            body,
+		Digest:          "sha256:" + hex.EncodeToString(digest[:]),
+		StatusCode:      resp.StatusCode,
+		DecodedBytes:    stats.decodedBytes,
+		Duration:        time.Since(start),
+		Truncated:       truncated,
+	}
+
+	if charset, ok := ResponseCharset(resp); ok {
+		result.Charset = charset
+	}
+	if trace, ok := ResponseTrace(resp); ok {
+		result.Trace = trace
+		result.WireBytes = trace.BodyBytes
+	}
+
+	return result, nil
+}
+
+// Reads up to `limit` bytes (unlimited, if not positive) from the body.
+func readBody(body io.Reader, limit int64) ([]byte, bool, error) {
+	if limit <= 0 {
+		data, err := io.ReadAll(body)
+		return data, false, err
+	}
+
+	data, err := io.ReadAll(io.LimitReader(body, limit+1))
+	if err != nil {
+		return nil, false, err
+	}
+	if int64(len(data)) > limit {
+		return data[:limit], true, nil
+	}
+	return data, false, nil
+}
+
+// Adapts the [HttpFetcher] to the scheme-agnostic [fetcher.Fetcher], for
+// http and https. The [FetchResult] is retained as [fetcher.Resource.Source].
+func (f *HttpFetcher) AsFetcher() fetcher.Fetcher {
+	return resourceFetcher{fetcher: f}
+}
+
+type resourceFetcher struct {
+	fetcher *HttpFetcher
+}
+
+func (r resourceFetcher) Fetch(ctx context.Context, u *url.URL) (*fetcher.Resource, error) {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("%w: %q", fetcher.ErrUnsupportedScheme, u.Scheme)
+	}
+
+	result, err := r.fetcher.Fetch(ctx, u.String())
+	if err != nil {
+		return nil, err
+	}
+
+	resource := &fetcher.Resource{
+		FetchedAt: result.FetchedAt,
+		URL:       result.URL,
+		Source:    result,
+		MediaType: result.ContentType(),
+		Body:      result.Body,
+	}
+	// The body is normalized to UTF-8, the original charset remains on the source
+	if result.Charset != nil {
+		resource.Charset = "utf-8"
+	}
+
+	return resource, nil
+}
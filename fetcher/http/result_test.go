@@ -0,0 +1,134 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/ritvikos/synapse/fetcher"
+	"github.com/ritvikos/synapse/pipeline"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _ pipeline.Document = (*FetchResult)(nil)
+
+func TestFetch(t *testing.T) {
+	// "café" in windows-1252
+	page := "<html><body>caf\xe9 " + strings.Repeat("x", 256) + "</body></html>"
+
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	_, _ = gz.Write([]byte(page))
+	require.NoError(t, gz.Close())
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/start", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/page", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/page", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=ISO-8859-1")
+		w.Header().Set("Content-Encoding", "gzip")
+		_, _ = w.Write(compressed.Bytes())
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	t.Run("result", func(t *testing.T) {
+		f, err := NewHttpFetcher(newNoRedirectClient(), WithRedirectPolicy(RedirectPolicy{}))
+		require.NoError(t, err)
+
+		result, err := f.Fetch(t.Context(), server.URL+"/start")
+		require.NoError(t, err)
+
+		expected := strings.Replace(page, "caf\xe9", "café", 1)
+		digest := sha256.Sum256([]byte(expected))
+
+		assert.Equal(t, http.StatusOK, result.StatusCode)
+		assert.Equal(t, server.URL+"/page", result.URL.String())
+		assert.Len(t, result.Redirects, 1)
+		assert.Equal(t, []string{"gzip"}, result.ContentEncoding)
+		assert.Equal(t, "text/html", result.ContentType())
+		require.NotNil(t, result.Charset)
+		assert.Equal(t, "windows-1252", result.Charset.Charset)
+		assert.Equal(t, expected, string(result.Body))
+		assert.Equal(t, "sha256:"+hex.EncodeToString(digest[:]), result.Digest)
+		assert.Equal(t, int64(compressed.Len()), result.WireBytes)
+		assert.Equal(t, int64(len(page)), result.DecodedBytes)
+		require.NotNil(t, result.Trace)
+		assert.Positive(t, result.Trace.Total)
+		assert.GreaterOrEqual(t, result.Duration, result.Trace.Total)
+		assert.False(t, result.Truncated)
+
+		body, err := io.ReadAll(result.Reader())
+		require.NoError(t, err)
+		assert.Equal(t, result.Body, body)
+	})
+
+	t.Run("scheme-agnostic fetcher", func(t *testing.T) {
+		f, err := NewHttpFetcher(server.Client())
+		require.NoError(t, err)
+
+		registry := fetcher.NewRegistry()
+		registry.Register(f.AsFetcher(), "http", "https")
+
+		u, err := url.Parse(server.URL + "/page")
+		require.NoError(t, err)
+
+		resource, err := registry.Fetch(t.Context(), u)
+		require.NoError(t, err)
+
+		assert.Equal(t, "text/html", resource.ContentType())
+		assert.Equal(t, "utf-8", resource.Charset)
+		require.IsType(t, &FetchResult{}, resource.Source)
+		assert.Equal(t, "windows-1252", resource.Source.(*FetchResult).Charset.Charset)
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		f, err := NewHttpFetcher(server.Client(), WithMaxBodySize(16))
+		require.NoError(t, err)
+
+		result, err := f.Fetch(t.Context(), server.URL+"/page")
+		require.NoError(t, err)
+
+		assert.True(t, result.Truncated)
+		assert.Len(t, result.Body, 16)
+	})
+
+	t.Run("truncated recording", func(t *testing.T) {
+		var recorded []*Exchange
+		recorder := exchangeRecorderFunc(func(exchange *Exchange) error {
+			recorded = append(recorded, exchange)
+			return nil
+		})
+
+		f, err := NewHttpFetcher(server.Client(), WithMaxBodySize(16), WithExchangeRecorder(recorder))
+		require.NoError(t, err)
+
+		// Closed unread, the remaining body is drained up to the limit
+		resp, err := f.Get(t.Context(), server.URL+"/page")
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+
+		require.Len(t, recorded, 1)
+		assert.True(t, recorded[0].Truncated)
+		assert.Equal(t, compressed.Bytes()[:16], recorded[0].Body)
+	})
+}
+
+type exchangeRecorderFunc func(*Exchange) error
+
+func (f exchangeRecorderFunc) Record(exchange *Exchange) error {
+	return f(exchange)
+}
@@ -0,0 +1,233 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// Idle per-host buckets are removed once there are more than these many
+	maxIdleBuckets = 1024
+	bucketIdleTime = time.Minute
+)
+
+type ThrottleConfig struct {
+	// Bytes per second across all the fetches, 0 for unlimited
+	Global int64
+
+	// Bytes per second per host (without port), 0 for unlimited
+	PerHost int64
+
+	// Bytes which can be read at once, after being idle.
+	// Defaults to one second worth of the respective limit.
+	Burst int64
+}
+
+func (c *ThrottleConfig) validate() error {
+	if c.Global < 0 || c.PerHost < 0 || c.Burst < 0 {
+		return errors.New("throttle: limits cannot be negative")
+	}
+	if c.Global == 0 && c.PerHost == 0 {
+		return errors.New("throttle: at least one of global or per-host limit is required")
+	}
+	return nil
+}
+
+// Token bucket, which can go into debt so that the reads are never split.
+type bucket struct {
+	last   time.Time
+	rate   float64
+	burst  float64
+	tokens float64
+}
+
+func newBucket(rate, burst int64, now time.Time) *bucket {
+	if burst == 0 {
+		burst = rate
+	}
+	return &bucket{
+		last:   now,
+		rate:   float64(rate),
+		burst:  float64(burst),
+		tokens: float64(burst),
+	}
+}
+
+// Consumes n tokens, returns the duration to wait for the debt to be repaid.
+func (b *bucket) reserve(n int, now time.Time) time.Duration {
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	b.tokens -= float64(n)
+
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+func (b *bucket) idle(now time.Time) bool {
+	return now.Sub(b.last) > bucketIdleTime
+}
+
+// Throttle limits the bandwidth of the response bodies, shared across all the
+// concurrent fetches (and fetchers) using it.
+//
+// # Example Usage
+//
+//	// 10 MB/s in total, at most 512 KB/s per host
+//	throttle, err := http.NewThrottle(http.ThrottleConfig{Global: 10 << 20, PerHost: 512 << 10})
+//
+//	f, err := http.NewHttpFetcher(client, http.WithThrottle(throttle))
+type Throttle struct {
+	global *bucket
+	hosts  map[string]*bucket
+	config ThrottleConfig
+	mu     sync.Mutex
+}
+
+func NewThrottle(config ThrottleConfig) (*Throttle, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	t := &Throttle{
+		hosts:  make(map[string]*bucket),
+		config: config,
+	}
+	if config.Global > 0 {
+		t.global = newBucket(config.Global, config.Burst, time.Now())
+	}
+	return t, nil
+}
+
+// Largest read permitted at once, so that a single read doesn't
+// exhaust the budget far ahead.
+func (t *Throttle) maxRead() int {
+	limit := t.config.Burst
+	if limit == 0 {
+		limit = t.config.Global
+		if t.config.PerHost > 0 && (limit == 0 || t.config.PerHost < limit) {
+			limit = t.config.PerHost
+		}
+	}
+	return int(min(limit, math.MaxInt32))
+}
+
+// Consumes n bytes from the global and the host budget, returns the duration to wait.
+func (t *Throttle) reserve(host string, n int) time.Duration {
+	now := time.Now()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var wait time.Duration
+	if t.global != nil {
+		wait = t.global.reserve(n, now)
+	}
+
+	if t.config.PerHost > 0 {
+		b, ok := t.hosts[host]
+		if !ok {
+			if len(t.hosts) >= maxIdleBuckets {
+				t.evict(now)
+			}
+			b = newBucket(t.config.PerHost, t.config.Burst, now)
+			t.hosts[host] = b
+		}
+		wait = max(wait, b.reserve(n, now))
+	}
+
+	return wait
+}
+
+// Must be called with the lock held.
+func (t *Throttle) evict(now time.Time) {
+	for host, b := range t.hosts {
+		if b.idle(now) {
+			delete(t.hosts, host)
+		}
+	}
+}
+
+// Delays the reads of the body as per the throttle, the total throttled time
+// is reported once the body is read completely or closed.
+type throttledBody struct {
+	ctx      context.Context
+	body     io.ReadCloser
+	throttle *Throttle
+	onDone   func(throttled time.Duration)
+	host     string
+	waited   time.Duration
+	done     bool
+}
+
+func newThrottledBody(req *http.Request, resp *http.Response, throttle *Throttle, onDone func(time.Duration)) *throttledBody {
+	return &throttledBody{
+		ctx:      req.Context(),
+		body:     resp.Body,
+		throttle: throttle,
+		onDone:   onDone,
+		host:     strings.ToLower(req.URL.Hostname()),
+	}
+}
+
+func (b *throttledBody) Read(p []byte) (int, error) {
+	if limit := b.throttle.maxRead(); len(p) > limit {
+		p = p[:limit]
+	}
+
+	n, err := b.body.Read(p)
+	if n > 0 {
+		if wait := b.throttle.reserve(b.host, n); wait > 0 {
+			if waitErr := b.wait(wait); waitErr != nil {
+				return n, waitErr
+			}
+		}
+	}
+
+	if err == io.EOF {
+		b.complete()
+	}
+	return n, err
+}
+
+func (b *throttledBody) Close() error {
+	err := b.body.Close()
+	b.complete()
+	return err
+}
+
+func (b *throttledBody) wait(d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	start := time.Now()
+	select {
+	case <-timer.C:
+		b.waited += d
+		return nil
+	case <-b.ctx.Done():
+		b.waited += time.Since(start)
+		return b.ctx.Err()
+	}
+}
+
+func (b *throttledBody) complete() {
+	if b.done {
+		return
+	}
+	b.done = true
+
+	if b.waited > 0 && b.onDone != nil {
+		b.onDone(b.waited)
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThrottle(t *testing.T) {
+	body := bytes.Repeat([]byte("x"), 4<<10)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+
+	var throttled atomic.Int64
+	hooks := NoopEventHook
+	hooks.OnThrottle = func(_ *http.Request, d time.Duration) {
+		throttled.Add(int64(d))
+	}
+
+	get := func(t *testing.T, f *HttpFetcher) {
+		resp, err := f.Get(t.Context(), server.URL)
+		require.NoError(t, err)
+
+		data, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		assert.Len(t, data, len(body))
+	}
+
+	t.Run("per host", func(t *testing.T) {
+		throttled.Store(0)
+
+		// 3KB beyond the burst, at 16KB/s
+		throttle, err := NewThrottle(ThrottleConfig{PerHost: 16 << 10, Burst: 1 << 10})
+		require.NoError(t, err)
+
+		f, err := NewHttpFetcher(server.Client(), WithThrottle(throttle), WithEventHooks(hooks))
+		require.NoError(t, err)
+
+		start := time.Now()
+		get(t, f)
+		assert.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond)
+		assert.GreaterOrEqual(t, time.Duration(throttled.Load()), 150*time.Millisecond)
+	})
+
+	t.Run("shared global budget", func(t *testing.T) {
+		// 7KB beyond the burst for both fetches, at 32KB/s
+		throttle, err := NewThrottle(ThrottleConfig{Global: 32 << 10, Burst: 1 << 10})
+		require.NoError(t, err)
+
+		f, err := NewHttpFetcher(server.Client(), WithThrottle(throttle))
+		require.NoError(t, err)
+
+		start := time.Now()
+		var wg sync.WaitGroup
+		for range 2 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				get(t, f)
+			}()
+		}
+		wg.Wait()
+
+		assert.GreaterOrEqual(t, time.Since(start), 180*time.Millisecond)
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		throttle, err := NewThrottle(ThrottleConfig{Global: 1 << 10})
+		require.NoError(t, err)
+
+		f, err := NewHttpFetcher(server.Client(), WithThrottle(throttle))
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+		defer cancel()
+
+		resp, err := f.Get(ctx, server.URL)
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+
+		_, err = io.ReadAll(resp.Body)
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		_, err := NewThrottle(ThrottleConfig{})
+		assert.Error(t, err)
+	})
+}
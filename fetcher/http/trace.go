@@ -0,0 +1,258 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// Timings of the phases of a single exchange. The phases which didn't occur
+// (eg: DNS and connect on a reused connection) are zero.
+type Timings struct {
+	DNS          time.Duration
+	Connect      time.Duration
+	TLSHandshake time.Duration
+
+	// Time to first byte, from sending the request until the first byte of the
+	// response was received.
+	TTFB time.Duration
+
+	// From the first byte of the response until the body was read completely (or closed)
+	Download time.Duration
+
+	// From sending the request until the body was read completely (or closed)
+	Total time.Duration
+}
+
+// Trace of a single exchange (i.e. redirect hops are traced individually)
+// made by [HttpFetcher], captured via [httptrace].
+//
+// Only the [http.Transport] based clients report the connection level details.
+type Trace struct {
+	// Time at which the request was handed over to the underlying client.
+	Start time.Time
+
+	// Remote address of the connection (eg: "93.184.216.34:443")
+	RemoteAddr string
+
+	// Certificate chain presented by the server
+	PeerCertificates []*x509.Certificate
+
+	Timings
+
+	// Raw (possibly compressed) response body bytes read
+	BodyBytes int64
+
+	// Time the reused connection was idle for
+	IdleTime time.Duration
+
+	// TLS version (eg: [tls.VersionTLS13]), 0 for plain HTTP
+	TLSVersion uint16
+
+	// Whether the connection was reused from the idle pool
+	ConnReused bool
+}
+
+// Remote IP of the connection, if known.
+func (t *Trace) RemoteIP() string {
+	host, _, err := net.SplitHostPort(t.RemoteAddr)
+	if err != nil {
+		return t.RemoteAddr
+	}
+	return host
+}
+
+// Name of the TLS version (eg: "TLS 1.3"), empty for plain HTTP.
+func (t *Trace) TLSVersionName() string {
+	if t.TLSVersion == 0 {
+		return ""
+	}
+	return tls.VersionName(t.TLSVersion)
+}
+
+// Returns the trace of the exchange which produced the response.
+//
+// The download timings are only available once the response body
+// is read completely or closed.
+func ResponseTrace(resp *http.Response) (*Trace, bool) {
+	if resp == nil || resp.Request == nil {
+		return nil, false
+	}
+	tracer, ok := resp.Request.Context().Value(tracerContextKey{}).(*tracer)
+	if !ok {
+		return nil, false
+	}
+	return tracer.snapshot(), true
+}
+
+type tracerContextKey struct{}
+
+// Collects the [httptrace] events of a single exchange, the events can
+// arrive concurrently (eg: parallel dials).
+type tracer struct {
+	dnsStart     time.Time
+	connectStart time.Time
+	tlsStart     time.Time
+	firstByte    time.Time
+	trace        Trace
+	mu           sync.Mutex
+	done         bool
+}
+
+// Attaches a new tracer to the request.
+func withTracer(req *http.Request) (*http.Request, *tracer) {
+	t := &tracer{}
+
+	clientTrace := &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) {
+			t.update(func(now time.Time) { t.dnsStart = now })
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			t.update(func(now time.Time) { t.trace.DNS = since(t.dnsStart, now) })
+		},
+		ConnectStart: func(string, string) {
+			t.update(func(now time.Time) {
+				if t.connectStart.IsZero() {
+					t.connectStart = now
+				}
+			})
+		},
+		ConnectDone: func(_, _ string, err error) {
+			if err != nil {
+				return
+			}
+			t.update(func(now time.Time) { t.trace.Connect = since(t.connectStart, now) })
+		},
+		TLSHandshakeStart: func() {
+			t.update(func(now time.Time) { t.tlsStart = now })
+		},
+		TLSHandshakeDone: func(state tls.ConnectionState, err error) {
+			if err != nil {
+				return
+			}
+			t.update(func(now time.Time) {
+				t.trace.TLSHandshake = since(t.tlsStart, now)
+				t.setTLS(&state)
+			})
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			t.update(func(time.Time) {
+				t.trace.ConnReused = info.Reused
+				t.trace.IdleTime = info.IdleTime
+				if info.Conn != nil {
+					t.trace.RemoteAddr = info.Conn.RemoteAddr().String()
+				}
+			})
+		},
+		GotFirstResponseByte: func() {
+			t.update(func(now time.Time) { t.firstByte = now })
+		},
+	}
+
+	ctx := context.WithValue(req.Context(), tracerContextKey{}, t)
+	ctx = httptrace.WithClientTrace(ctx, clientTrace)
+
+	t.trace.Start = time.Now()
+	return req.WithContext(ctx), t
+}
+
+func (t *tracer) update(fn func(now time.Time)) {
+	now := time.Now()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	fn(now)
+}
+
+// Must be called with the lock held.
+func (t *tracer) setTLS(state *tls.ConnectionState) {
+	t.trace.TLSVersion = state.Version
+	t.trace.PeerCertificates = state.PeerCertificates
+}
+
+// Records the receipt of the response header.
+func (t *tracer) gotResponse(resp *http.Response) {
+	t.update(func(now time.Time) {
+		// Clients not reporting the first byte (eg: non-transport based)
+		if t.firstByte.IsZero() {
+			t.firstByte = now
+		}
+		t.trace.TTFB = since(t.trace.Start, t.firstByte)
+
+		if resp.TLS != nil && t.trace.TLSVersion == 0 {
+			t.setTLS(resp.TLS)
+		}
+	})
+}
+
+// Completes the trace, returns false if it was already completed.
+func (t *tracer) finish(bodyBytes int64) (*Trace, bool) {
+	now := time.Now()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.done {
+		return nil, false
+	}
+	t.done = true
+
+	t.trace.BodyBytes = bodyBytes
+	t.trace.Total = since(t.trace.Start, now)
+	if !t.firstByte.IsZero() {
+		t.trace.Download = since(t.firstByte, now)
+	}
+
+	trace := t.trace
+	return &trace, true
+}
+
+func (t *tracer) snapshot() *Trace {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	trace := t.trace
+	return &trace
+}
+
+func since(start, end time.Time) time.Duration {
+	if start.IsZero() {
+		return 0
+	}
+	return end.Sub(start)
+}
+
+// Completes the trace once the response body is read completely or closed.
+type tracingBody struct {
+	body   io.ReadCloser
+	tracer *tracer
+	onDone func(*Trace)
+	n      int64
+}
+
+func (b *tracingBody) Read(p []byte) (int, error) {
+	n, err := b.body.Read(p)
+	b.n += int64(n)
+	if err == io.EOF {
+		b.complete()
+	}
+	return n, err
+}
+
+func (b *tracingBody) Close() error {
+	err := b.body.Close()
+	b.complete()
+	return err
+}
+
+func (b *tracingBody) complete() {
+	if trace, ok := b.tracer.finish(b.n); ok && b.onDone != nil {
+		b.onDone(trace)
+	}
+}
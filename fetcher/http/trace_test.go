@@ -0,0 +1,84 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"crypto/tls"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrace(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte("hello"))
+	}))
+	t.Cleanup(server.Close)
+
+	var traces []*Trace
+	hooks := NoopEventHook
+	hooks.OnTrace = func(_ *http.Request, trace *Trace) {
+		traces = append(traces, trace)
+	}
+
+	f, err := NewHttpFetcher(server.Client(), WithEventHooks(hooks))
+	require.NoError(t, err)
+
+	get := func() *Trace {
+		resp, err := f.Get(t.Context(), server.URL)
+		require.NoError(t, err)
+
+		_, err = io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+
+		trace, ok := ResponseTrace(resp)
+		require.True(t, ok)
+		return trace
+	}
+
+	first := get()
+	assert.False(t, first.ConnReused)
+	assert.Equal(t, "127.0.0.1", first.RemoteIP())
+	assert.Equal(t, uint16(tls.VersionTLS13), first.TLSVersion)
+	assert.Equal(t, "TLS 1.3", first.TLSVersionName())
+	assert.NotEmpty(t, first.PeerCertificates)
+	assert.Positive(t, first.Connect)
+	assert.Positive(t, first.TLSHandshake)
+	assert.Positive(t, first.TTFB)
+	assert.GreaterOrEqual(t, first.Total, first.TTFB+first.Download)
+	assert.Equal(t, int64(len("hello")), first.BodyBytes)
+
+	second := get()
+	assert.True(t, second.ConnReused)
+	assert.Zero(t, second.Connect)
+
+	require.Len(t, traces, 2, "hook must be called once per exchange")
+	assert.Equal(t, first, traces[0])
+
+	t.Run("failed exchange", func(t *testing.T) {
+		traces = nil
+		f, err := NewHttpFetcher(clientFunc(func(*http.Request) (*http.Response, error) {
+			return nil, errors.New("connection refused")
+		}), WithEventHooks(hooks))
+		require.NoError(t, err)
+
+		_, err = f.Get(t.Context(), "http://example.com")
+		require.Error(t, err)
+		require.Len(t, traces, 1)
+		assert.Zero(t, traces[0].TTFB)
+	})
+}
+
+type clientFunc func(*http.Request) (*http.Response, error)
+
+func (fn clientFunc) Do(req *http.Request) (*http.Response, error) {
+	return fn(req)
+}
@@ -3,7 +3,10 @@
 
 package http
 
-import "net/http"
+import (
+	"net/http"
+	"time"
+)
 
 type HttpClient interface {
 	Do(req *http.Request) (*http.Response, error)
@@ -15,6 +18,17 @@ type EventHooks struct {
 	OnError    func(*http.Request, error)
 	OnChunk    func([]byte)
 
+	// Called when the circuit for a host changes its state (optional)
+	OnCircuitStateChange func(host string, from, to CircuitState)
+
+	// Called with the timings and connection details of each exchange (optional),
+	// once its response body is read completely or closed, or once it failed.
+	OnTrace func(req *http.Request, trace *Trace)
+
+	// Called with the total time the response body was delayed by the throttle
+	// (optional), once it's read completely or closed.
+	OnThrottle func(req *http.Request, throttled time.Duration)
+
 	// TODO: expose parser
 	OnScraped func(*http.Response)
 }
@@ -16,3 +16,30 @@ func (rc *readCloser) Close() error {
 	}
 	return nil
 }
+
+// Closes the decoder along with the underlying body,
+// as the decoders from the standard library don't close the source.
+type decoderReadCloser struct {
+	io.ReadCloser
+	body io.Closer
+}
+
+func (d *decoderReadCloser) Close() error {
+	err := d.ReadCloser.Close()
+	if bodyErr := d.body.Close(); bodyErr != nil {
+		return bodyErr
+	}
+	return err
+}
+
+// Counts the bytes read from the underlying reader.
+type countingReadCloser struct {
+	io.ReadCloser
+	n *int64
+}
+
+func (c *countingReadCloser) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	*c.n += int64(n)
+	return n, err
+}
@@ -5,9 +5,13 @@ package backend
 
 import (
 	"context"
+	"errors"
 	"time"
 )
 
+// Returned by [Store] and [Cache] implementations, when the key doesn't exist.
+var ErrNotFound = errors.New("backend: key not found")
+
 // Generic Queue interface
 type Queue[T any] interface {
 	// Insert items into the queue.
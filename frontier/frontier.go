@@ -131,6 +131,18 @@ func (f *Frontier[T]) robotsWorker() {
 				return
 			}
 
+			// robots.txt only applies to the web (eg: not to file:// or data: URLs)
+			if url.Scheme != "http" && url.Scheme != "https" {
+				task.ExecuteAt = time.Now()
+
+				select {
+				case f.robotsResolvedCh <- task:
+				case <-f.ctx.Done():
+					return
+				}
+				continue
+			}
+
 			entry, err := f.robotstxt.Resolve(f.ctx, url.Host)
 			if err != nil {
 				log.Println("error resolving robots.txt for host", url.Host, ":", err)
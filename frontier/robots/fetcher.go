@@ -13,11 +13,13 @@ type DefaultRobotsFetcher struct {
 	client http.Client
 }
 
+// Fetches `robots.txt` with the given client, defaults to 12 seconds timeout if unset.
 func NewDefaultRobotsTxtFetcher(client http.Client) *DefaultRobotsFetcher {
+	if client.Timeout == 0 {
+		client.Timeout = 12 * time.Second
+	}
 	return &DefaultRobotsFetcher{
-		client: http.Client{
-			Timeout: 12 * time.Second,
-		},
+		client: client,
 	}
 }
 
@@ -7,6 +7,13 @@ import (
 
 // Configures the [RobotsResolver] instance
 type RobotsConfig struct {
+	// Resolves the user-agent token for the rule lookup per origin, so that it
+	// matches the user-agent the origin is crawled with (optional).
+	// (eg: `ProfileSet.RobotsToken` of the HTTP fetcher)
+	//
+	// Falls back to [RobotsConfig.UserAgent], if it returns an empty token.
+	UserAgentFor func(origin string) string
+
 	// User-agent to be used when fetching robots.txt from hosts
 	UserAgent string
 
@@ -23,3 +30,12 @@ func (c *RobotsConfig) validate() error {
 	}
 	return nil
 }
+
+func (c *RobotsConfig) userAgent(origin string) string {
+	if c.UserAgentFor != nil {
+		if agent := c.UserAgentFor(origin); agent != "" {
+			return agent
+		}
+	}
+	return c.UserAgent
+}
@@ -101,7 +101,7 @@ func (r *RobotsResolver) get(ctx context.Context, origin string) (*RobotsEntry,
 	}
 
 	return &RobotsEntry{
-		Group:       data.FindGroup(r.config.UserAgent),
+		Group:       data.FindGroup(r.config.userAgent(origin)),
 		LastFetched: time.Now(),
 	}, nil
 }
@@ -0,0 +1,78 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package robots
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ritvikos/synapse/frontier/backend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubFetcher string
+
+func (s stubFetcher) Fetch(context.Context, string) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(string(s))),
+	}, nil
+}
+
+type mapCache struct {
+	entries map[string]*RobotsEntry
+	mu      sync.Mutex
+}
+
+func (m *mapCache) Set(_ context.Context, key string, value *RobotsEntry, _ time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries[key] = value
+	return nil
+}
+
+func (m *mapCache) Get(_ context.Context, key string) (*RobotsEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if entry, ok := m.entries[key]; ok {
+		return entry, nil
+	}
+	return nil, backend.ErrNotFound
+}
+
+func (m *mapCache) Purge(context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	clear(m.entries)
+	return nil
+}
+
+func TestRobotsResolverUserAgent(t *testing.T) {
+	robotsTxt := stubFetcher("User-agent: synapse\nDisallow: /private\n\nUser-agent: *\nDisallow: /\n")
+
+	tokens := map[string]string{"bot.example.com": "synapse"}
+
+	resolver, err := NewRobotsResolver(RobotsConfig{
+		UserAgent:    "generic",
+		UserAgentFor: func(origin string) string { return tokens[origin] },
+		TTL:          time.Hour,
+	}, robotsTxt, &mapCache{entries: map[string]*RobotsEntry{}})
+	require.NoError(t, err)
+
+	entry, err := resolver.Resolve(t.Context(), "bot.example.com")
+	require.NoError(t, err)
+	assert.True(t, entry.Test("/public"))
+	assert.False(t, entry.Test("/private"))
+
+	// Falls back to the configured user-agent
+	entry, err = resolver.Resolve(t.Context(), "other.example.com")
+	require.NoError(t, err)
+	assert.False(t, entry.Test("/public"))
+}
@@ -5,6 +5,8 @@ go 1.24.9
 require (
 	github.com/PuerkitoBio/goquery v1.11.0
 	github.com/andybalholm/brotli v1.2.0
+	github.com/antchfx/htmlquery v1.3.6
+	github.com/antchfx/xpath v1.3.6
 	github.com/brianvoe/gofakeit/v7 v7.12.1
 	github.com/klauspost/compress v1.18.1
 	github.com/saintfish/chardet v0.0.0-20230101081208-5e3ef4b5456d
@@ -12,12 +14,13 @@ require (
 	github.com/temoto/robotstxt v1.1.2
 	golang.org/x/net v0.47.0
 	golang.org/x/sync v0.19.0
+	golang.org/x/text v0.31.0
 )
 
 require (
 	github.com/andybalholm/cascadia v1.3.3 // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/golang/groupcache v0.0.0-20210331224755-41bb18bfe9da // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	golang.org/x/text v0.31.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,39 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ritvikos/synapse/robots/directives"
+)
+
+// Returned by the processors to drop the data without writing it to the sink,
+// which isn't treated as a failure of the pipeline.
+var ErrSkip = errors.New("pipeline: skipped")
+
+// Processor skipping the data matching the predicate.
+type SkipIf[T any] func(data T) bool
+
+func (f SkipIf[T]) Process(data T) (T, error) {
+	if f(data) {
+		return data, ErrSkip
+	}
+	return data, nil
+}
+
+// Processor skipping the pages which must not be indexed, as per their
+// robots directives (i.e. noindex, or past `unavailable_after`).
+//
+// # Example Usage
+//
+//	pipeline.WithProcessors(pipeline.SkipNoIndex(func(p Page) directives.Directives {
+//		return p.Robots
+//	}))
+func SkipNoIndex[T any](robots func(data T) directives.Directives) SkipIf[T] {
+	return func(data T) bool {
+		return robots(data).NoIndexAt(time.Now())
+	}
+}
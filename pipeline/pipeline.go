@@ -4,6 +4,7 @@
 package pipeline
 
 import (
+	"errors"
 	"fmt"
 	"io"
 )
@@ -22,6 +23,9 @@ func (ctx *ExecutionContext[T]) ExecuteDefault(r io.Reader) error {
 
 	for _, proc := range ctx.processors {
 		data, err = proc.Process(data)
+		if errors.Is(err, ErrSkip) {
+			return nil
+		}
 		if err != nil {
 			return fmt.Errorf("processor %T failed: %w", proc, err)
 		}
@@ -3,7 +3,21 @@
 
 package pipeline
 
-import "strings"
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var ErrNoHandler = errors.New("pipeline: no handler registered for the content type")
+
+// Document is a fetched resource, routed by its content type.
+// (eg: `FetchResult` from the HTTP fetcher)
+type Document interface {
+	ContentType() string
+	Reader() io.Reader
+}
 
 type Router struct {
 	handlers map[string]Handler
@@ -24,3 +38,12 @@ func (r *Router) Route(contentType string) (Handler, bool) {
 	handler, ok := r.handlers[contentType]
 	return handler, ok
 }
+
+// Executes the handler registered for the content type of the document.
+func (r *Router) Dispatch(doc Document) error {
+	handler, ok := r.Route(doc.ContentType())
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrNoHandler, doc.ContentType())
+	}
+	return handler.Execute(doc.Reader())
+}
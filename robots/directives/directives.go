@@ -0,0 +1,184 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package directives
+
+import (
+	"strings"
+	"time"
+)
+
+// Header carrying the page-level directives, for non-HTML content as well.
+const HeaderXRobotsTag = "X-Robots-Tag"
+
+// Directives are the page-level indexing rules, declared via
+// `<meta name="robots">` (or `<meta name="<user-agent>">`) and the
+// `X-Robots-Tag` header, unlike robots.txt which controls the crawling.
+type Directives struct {
+	// The page must not be indexed after this time (zero if unset)
+	UnavailableAfter time.Time
+
+	// The page must not be indexed (eg: sinks should skip it)
+	NoIndex bool
+
+	// The links of the page must not be followed
+	NoFollow bool
+
+	// A cached copy of the page must not be served
+	NoArchive bool
+
+	// A snippet of the page must not be shown
+	NoSnippet bool
+
+	// The images of the page must not be indexed
+	NoImageIndex bool
+}
+
+// Whether the page must not be indexed at the time.
+func (d Directives) NoIndexAt(now time.Time) bool {
+	return d.NoIndex || (!d.UnavailableAfter.IsZero() && !now.Before(d.UnavailableAfter))
+}
+
+// Combines the directives, the most restrictive ones win.
+func (d Directives) Merge(other Directives) Directives {
+	merged := Directives{
+		UnavailableAfter: d.UnavailableAfter,
+		NoIndex:          d.NoIndex || other.NoIndex,
+		NoFollow:         d.NoFollow || other.NoFollow,
+		NoArchive:        d.NoArchive || other.NoArchive,
+		NoSnippet:        d.NoSnippet || other.NoSnippet,
+		NoImageIndex:     d.NoImageIndex || other.NoImageIndex,
+	}
+	if !other.UnavailableAfter.IsZero() && (merged.UnavailableAfter.IsZero() || other.UnavailableAfter.Before(merged.UnavailableAfter)) {
+		merged.UnavailableAfter = other.UnavailableAfter
+	}
+	return merged
+}
+
+// Parses the comma-separated directives (eg: the `content` of the meta tag).
+// The unknown directives are ignored.
+func Parse(content string) Directives {
+	var d Directives
+	d.parse(content)
+	return d
+}
+
+// Parses the values of the `X-Robots-Tag` headers applicable to the
+// user-agent token, i.e. the unscoped ones (eg: "noindex") and the ones
+// scoped to the user-agent (eg: "synapse: nofollow").
+func ParseXRobotsTag(values []string, userAgent string) Directives {
+	var d Directives
+	for _, value := range values {
+		scope, rules, scoped := cutScope(value)
+		if scoped && !strings.EqualFold(scope, userAgent) {
+			continue
+		}
+		d.parse(rules)
+	}
+	return d
+}
+
+// Parses the content of `<meta name>` tags applicable to the user-agent token,
+// i.e. the ones named "robots" or after the user-agent.
+func ParseMeta(name, content, userAgent string) (Directives, bool) {
+	name = strings.TrimSpace(name)
+	if !strings.EqualFold(name, "robots") && (userAgent == "" || !strings.EqualFold(name, userAgent)) {
+		return Directives{}, false
+	}
+	return Parse(content), true
+}
+
+func (d *Directives) parse(content string) {
+	for _, directive := range splitDirectives(content) {
+		name, value, _ := strings.Cut(directive, ":")
+		name = strings.ToLower(strings.TrimSpace(name))
+
+		switch name {
+		case "noindex":
+			d.NoIndex = true
+		case "nofollow":
+			d.NoFollow = true
+		case "none":
+			d.NoIndex = true
+			d.NoFollow = true
+		case "noarchive", "nocache":
+			d.NoArchive = true
+		case "nosnippet":
+			d.NoSnippet = true
+		case "noimageindex":
+			d.NoImageIndex = true
+		case "unavailable_after":
+			if t, ok := parseDirectiveDate(strings.TrimSpace(value)); ok {
+				if d.UnavailableAfter.IsZero() || t.Before(d.UnavailableAfter) {
+					d.UnavailableAfter = t
+				}
+			}
+		}
+	}
+}
+
+// Splits at the commas, except for the ones within the dates of
+// `unavailable_after` (eg: "unavailable_after: Friday, 25-Jun-10 15:00:00 GMT").
+func splitDirectives(content string) []string {
+	parts := strings.Split(content, ",")
+
+	directives := make([]string, 0, len(parts))
+	for i := 0; i < len(parts); i++ {
+		part := parts[i]
+		name, value, found := strings.Cut(part, ":")
+		// A bare `unavailable_after` (without the date) is a plain token
+		if found && strings.EqualFold(strings.TrimSpace(name), "unavailable_after") && i+1 < len(parts) {
+			if _, ok := parseDirectiveDate(strings.TrimSpace(value)); !ok {
+				part += "," + parts[i+1]
+				i++
+			}
+		}
+		directives = append(directives, part)
+	}
+	return directives
+}
+
+// Known directives with a value, which aren't user-agent scopes.
+var valuedDirectives = map[string]struct{}{
+	"unavailable_after": {},
+	"max-snippet":       {},
+	"max-image-preview": {},
+	"max-video-preview": {},
+}
+
+// Splits the user-agent scope of the header value (eg: "synapse: noindex").
+func cutScope(value string) (scope, rules string, scoped bool) {
+	prefix, rest, found := strings.Cut(value, ":")
+	if !found {
+		return "", value, false
+	}
+
+	prefix = strings.TrimSpace(prefix)
+	if prefix == "" || strings.ContainsAny(prefix, ", ") {
+		return "", value, false
+	}
+	if _, ok := valuedDirectives[strings.ToLower(prefix)]; ok {
+		return "", value, false
+	}
+	return prefix, rest, true
+}
+
+var directiveDateLayouts = []string{
+	time.RFC3339,
+	time.RFC1123,
+	time.RFC1123Z,
+	time.RFC850,
+	time.RFC822,
+	"2006-01-02",
+	"02 Jan 2006 15:04:05 MST",
+	"2 Jan 2006 15:04:05 MST",
+}
+
+func parseDirectiveDate(value string) (time.Time, bool) {
+	for _, layout := range directiveDateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
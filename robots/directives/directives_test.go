@@ -0,0 +1,79 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package directives
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDirectives(t *testing.T) {
+	t.Run("meta content", func(t *testing.T) {
+		assert.Equal(t, Directives{NoIndex: true, NoFollow: true}, Parse("None"))
+		assert.Equal(t, Directives{NoArchive: true, NoSnippet: true}, Parse(" noarchive ,NOSNIPPET, max-snippet:-1, unknown"))
+		assert.Equal(t, Directives{}, Parse("all"))
+	})
+
+	t.Run("unavailable after", func(t *testing.T) {
+		d := Parse("noarchive, unavailable_after: Friday, 25-Jun-10 15:00:00 GMT")
+		assert.True(t, d.NoArchive)
+		assert.Equal(t, time.Date(2010, time.June, 25, 15, 0, 0, 0, time.UTC), d.UnavailableAfter.UTC())
+		assert.False(t, d.NoIndexAt(time.Date(2010, time.June, 1, 0, 0, 0, 0, time.UTC)))
+		assert.True(t, d.NoIndexAt(time.Date(2010, time.July, 1, 0, 0, 0, 0, time.UTC)))
+	})
+
+	t.Run("bare unavailable after", func(t *testing.T) {
+		assert.NotPanics(t, func() {
+			d := Parse("unavailable_after, noindex")
+			assert.True(t, d.NoIndex)
+			assert.True(t, d.UnavailableAfter.IsZero())
+		})
+		assert.NotPanics(t, func() {
+			d := ParseXRobotsTag([]string{"unavailable_after, nofollow"}, "synapse")
+			assert.True(t, d.NoFollow)
+		})
+	})
+
+	t.Run("x-robots-tag scopes", func(t *testing.T) {
+		values := []string{
+			"noarchive",
+			"synapse: noindex",
+			"otherbot: nofollow, noimageindex",
+			"unavailable_after: 2030-01-02T00:00:00Z",
+		}
+
+		d := ParseXRobotsTag(values, "Synapse")
+		assert.True(t, d.NoIndex)
+		assert.True(t, d.NoArchive)
+		assert.False(t, d.NoFollow, "scoped to the other user-agent")
+		assert.Equal(t, 2030, d.UnavailableAfter.Year())
+
+		d = ParseXRobotsTag(values, "otherbot")
+		assert.False(t, d.NoIndex)
+		assert.True(t, d.NoFollow)
+		assert.True(t, d.NoImageIndex)
+	})
+
+	t.Run("meta names", func(t *testing.T) {
+		_, ok := ParseMeta("description", "noindex", "synapse")
+		assert.False(t, ok)
+
+		d, ok := ParseMeta("Synapse", "nofollow", "synapse")
+		assert.True(t, ok)
+		assert.True(t, d.NoFollow)
+
+		_, ok = ParseMeta("googlebot", "noindex", "synapse")
+		assert.False(t, ok)
+	})
+
+	t.Run("merge", func(t *testing.T) {
+		early := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		late := early.AddDate(1, 0, 0)
+
+		d := Directives{NoIndex: true, UnavailableAfter: late}.Merge(Directives{NoFollow: true, UnavailableAfter: early})
+		assert.Equal(t, Directives{NoIndex: true, NoFollow: true, UnavailableAfter: early}, d)
+	})
+}
@@ -0,0 +1,105 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package warc
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+var ErrMalformedRecord = errors.New("warc: malformed record")
+
+// Reader reads WARC records sequentially from plain or
+// gzip-compressed (whole file or per-record) streams.
+type Reader struct {
+	r *bufio.Reader
+}
+
+func NewReader(r io.Reader) (*Reader, error) {
+	br := bufio.NewReader(r)
+
+	magic, err := br.Peek(2)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	if len(magic) == 2 && magic[0] == 0x1f && magic[1] == 0x8b {
+		// Consecutive gzip members are read as a single stream.
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("warc: failed to create gzip reader: %w", err)
+		}
+		br = bufio.NewReader(gz)
+	}
+
+	return &Reader{r: br}, nil
+}
+
+// Returns the next record, or [io.EOF] when there are no more records.
+func (r *Reader) Next() (*Record, error) {
+	version, err := r.readLine()
+	for err == nil && version == "" {
+		// Tolerate stray blank lines between records
+		version, err = r.readLine()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.HasPrefix(version, "WARC/") {
+		return nil, fmt.Errorf("%w: unexpected version line %q", ErrMalformedRecord, version)
+	}
+
+	record := &Record{}
+	for {
+		line, err := r.readLine()
+		if err != nil {
+			return nil, fmt.Errorf("%w: truncated header: %w", ErrMalformedRecord, err)
+		}
+		if line == "" {
+			break
+		}
+
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("%w: invalid header line %q", ErrMalformedRecord, line)
+		}
+		record.Header.Add(strings.TrimSpace(name), strings.TrimSpace(value))
+	}
+
+	length, err := strconv.ParseInt(record.Header.Get(FieldContentLength), 10, 64)
+	if err != nil || length < 0 {
+		return nil, fmt.Errorf("%w: invalid content length", ErrMalformedRecord)
+	}
+
+	var content bytes.Buffer
+	if _, err := io.CopyN(&content, r.r, length); err != nil {
+		return nil, fmt.Errorf("%w: truncated block: %w", ErrMalformedRecord, err)
+	}
+	record.Content = content.Bytes()
+
+	trailer := make([]byte, 4)
+	if _, err := io.ReadFull(r.r, trailer); err != nil || string(trailer) != "\r\n\r\n" {
+		return nil, fmt.Errorf("%w: missing record trailer", ErrMalformedRecord)
+	}
+
+	return record, nil
+}
+
+func (r *Reader) readLine() (string, error) {
+	line, err := r.r.ReadString('\n')
+	if err != nil {
+		if err == io.EOF && line != "" {
+			return "", io.ErrUnexpectedEOF
+		}
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
@@ -0,0 +1,150 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package warc
+
+import (
+	"crypto/rand"
+	"crypto/sha1" // #nosec G505 -- SHA-1 is the digest algorithm used by WARC tooling, not for security.
+	"encoding/base32"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const Version = "WARC/1.1"
+
+// Media types of the record blocks
+const (
+	ContentTypeHttpRequest  = "application/http;msgtype=request"
+	ContentTypeHttpResponse = "application/http;msgtype=response"
+	ContentTypeWarcFields   = "application/warc-fields"
+)
+
+// Named fields of the WARC record header
+const (
+	FieldType              = "WARC-Type"
+	FieldRecordID          = "WARC-Record-ID"
+	FieldDate              = "WARC-Date"
+	FieldTargetURI         = "WARC-Target-URI"
+	FieldConcurrentTo      = "WARC-Concurrent-To"
+	FieldRefersTo          = "WARC-Refers-To"
+	FieldRefersToTargetURI = "WARC-Refers-To-Target-URI"
+	FieldRefersToDate      = "WARC-Refers-To-Date"
+	FieldBlockDigest       = "WARC-Block-Digest"
+	FieldPayloadDigest     = "WARC-Payload-Digest"
+	FieldIPAddress         = "WARC-IP-Address"
+	FieldTruncated         = "WARC-Truncated"
+	FieldWarcinfoID        = "WARC-Warcinfo-ID"
+	FieldFilename          = "WARC-Filename"
+	FieldProfile           = "WARC-Profile"
+	FieldContentType       = "Content-Type"
+	FieldContentLength     = "Content-Length"
+)
+
+// Revisit profile for payloads identical to a previously captured one.
+const ProfileIdenticalPayloadDigest = "http://netpreserve.org/warc/1.1/revisit/identical-payload-digest"
+
+type RecordType string
+
+const (
+	TypeWarcinfo RecordType = "warcinfo"
+	TypeRequest  RecordType = "request"
+	TypeResponse RecordType = "response"
+	TypeMetadata RecordType = "metadata"
+	TypeRevisit  RecordType = "revisit"
+	TypeResource RecordType = "resource"
+)
+
+// Field is a single named field of the record header.
+type Field struct {
+	Name  string
+	Value string
+}
+
+// Header holds the named fields of a record, in the order they're written.
+// Field names are matched case-insensitively.
+type Header []Field
+
+func (h Header) Get(name string) string {
+	for _, field := range h {
+		if strings.EqualFold(field.Name, name) {
+			return field.Value
+		}
+	}
+	return ""
+}
+
+// Replaces the first field with the given name, or appends it.
+func (h *Header) Set(name, value string) {
+	for i, field := range *h {
+		if strings.EqualFold(field.Name, name) {
+			(*h)[i].Value = value
+			return
+		}
+	}
+	h.Add(name, value)
+}
+
+func (h *Header) Add(name, value string) {
+	*h = append(*h, Field{Name: name, Value: value})
+}
+
+type Record struct {
+	Header Header
+	// Record block, its length determines the `Content-Length` field.
+	Content []byte
+}
+
+func (r *Record) Type() RecordType {
+	return RecordType(r.Header.Get(FieldType))
+}
+
+func (r *Record) ID() string {
+	return r.Header.Get(FieldRecordID)
+}
+
+// Creates a record of the given type with a fresh record ID and date.
+func NewRecord(recordType RecordType, date time.Time) (*Record, error) {
+	id, err := NewRecordID()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Record{
+		Header: Header{
+			{Name: FieldType, Value: string(recordType)},
+			{Name: FieldRecordID, Value: id},
+			{Name: FieldDate, Value: FormatDate(date)},
+		},
+	}, nil
+}
+
+// Generates a random (v4) UUID in `<urn:uuid:...>` form.
+func NewRecordID() (string, error) {
+	var uuid [16]byte
+	if _, err := rand.Read(uuid[:]); err != nil {
+		return "", fmt.Errorf("warc: failed to generate record ID: %w", err)
+	}
+	uuid[6] = (uuid[6] & 0x0f) | 0x40
+	uuid[8] = (uuid[8] & 0x3f) | 0x80
+
+	id := hex.EncodeToString(uuid[:])
+	return "<urn:uuid:" + id[0:8] + "-" + id[8:12] + "-" + id[12:16] + "-" + id[16:20] + "-" + id[20:] + ">", nil
+}
+
+// WARC 1.1 dates are UTC timestamps in W3C format, with sub-second precision.
+func FormatDate(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05.000000Z")
+}
+
+func ParseDate(value string) (time.Time, error) {
+	return time.Parse(time.RFC3339Nano, value)
+}
+
+// Computes labelled SHA-1 digest, in the base32 form used by most WARC tooling.
+func Digest(data []byte) string {
+	sum := sha1.Sum(data) // #nosec G401
+	return "sha1:" + base32.StdEncoding.EncodeToString(sum[:])
+}
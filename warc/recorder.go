@@ -0,0 +1,263 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package warc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	fetcher "github.com/ritvikos/synapse/fetcher/http"
+)
+
+// CaptureRef identifies a previously recorded response.
+type CaptureRef struct {
+	Date      time.Time
+	RecordID  string
+	TargetURI string
+}
+
+// DigestIndex tracks payload digests of recorded responses,
+// to record duplicate payloads as revisit records instead.
+type DigestIndex interface {
+	Lookup(digest string) (CaptureRef, bool)
+	Add(digest string, ref CaptureRef)
+}
+
+// In-memory [DigestIndex], the first capture of a payload wins.
+type MemoryIndex struct {
+	refs map[string]CaptureRef
+	mu   sync.RWMutex
+}
+
+func NewMemoryIndex() *MemoryIndex {
+	return &MemoryIndex{
+		refs: make(map[string]CaptureRef),
+	}
+}
+
+func (m *MemoryIndex) Lookup(digest string) (CaptureRef, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	ref, ok := m.refs[digest]
+	return ref, ok
+}
+
+func (m *MemoryIndex) Add(digest string, ref CaptureRef) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.refs[digest]; !ok {
+		m.refs[digest] = ref
+	}
+}
+
+// Configures the [Recorder] instance
+type RecorderOption func(*Recorder)
+
+// Records responses with already seen payloads as revisit records
+// (identical-payload-digest profile).
+func WithDigestIndex(index DigestIndex) RecorderOption {
+	return func(r *Recorder) {
+		r.index = index
+	}
+}
+
+// Appends additional fields to the metadata record of every capture.
+func WithMetadata(fn func(exchange *fetcher.Exchange) Header) RecorderOption {
+	return func(r *Recorder) {
+		r.metadata = fn
+	}
+}
+
+// Recorder converts the exchanges observed by [fetcher.HttpFetcher] into
+// WARC records and writes them to the configured [RecordWriter].
+//
+// Every capture produces a response (or revisit), request and metadata record.
+//
+// # Example Usage
+//
+//	writer, err := warc.NewSpoolWriter(spooler, warc.SpoolWriterConfig{
+//		Prefix:      "synapse",
+//		MaxFileSize: 1 * 1024 * 1024 * 1024, // 1 GB per WARC file
+//	})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer writer.Close()
+//
+//	recorder := warc.NewRecorder(writer, warc.WithDigestIndex(warc.NewMemoryIndex()))
+//	f, err := http.NewHttpFetcher(client, http.WithExchangeRecorder(recorder))
+type Recorder struct {
+	writer   RecordWriter
+	index    DigestIndex
+	metadata func(exchange *fetcher.Exchange) Header
+}
+
+func NewRecorder(writer RecordWriter, opts ...RecorderOption) *Recorder {
+	recorder := &Recorder{
+		writer: writer,
+	}
+
+	for _, opt := range opts {
+		opt(recorder)
+	}
+
+	return recorder
+}
+
+func (r *Recorder) Record(exchange *fetcher.Exchange) error {
+	targetURI := exchange.Request.URL.String()
+	payloadDigest := Digest(exchange.Body)
+
+	response, err := r.responseRecord(exchange, targetURI, payloadDigest)
+	if err != nil {
+		return err
+	}
+
+	request, err := NewRecord(TypeRequest, exchange.Date)
+	if err != nil {
+		return err
+	}
+	request.Header.Add(FieldTargetURI, targetURI)
+	request.Header.Add(FieldConcurrentTo, response.ID())
+	request.Header.Add(FieldContentType, ContentTypeHttpRequest)
+	request.Content = requestBlock(exchange.Request)
+
+	metadata, err := NewRecord(TypeMetadata, exchange.Date)
+	if err != nil {
+		return err
+	}
+	metadata.Header.Add(FieldTargetURI, targetURI)
+	metadata.Header.Add(FieldConcurrentTo, response.ID())
+	metadata.Header.Add(FieldContentType, ContentTypeWarcFields)
+	metadata.Content = r.metadataBlock(exchange)
+
+	// Recorded once the body is closed, which may be past the cancellation
+	ctx := context.WithoutCancel(exchange.Request.Context())
+	if err := r.writer.WriteRecords(ctx, response, request, metadata); err != nil {
+		return fmt.Errorf("warc: failed to write records for %s: %w", targetURI, err)
+	}
+
+	if r.index != nil && isIndexable(exchange) && response.Type() == TypeResponse {
+		r.index.Add(payloadDigest, CaptureRef{
+			Date:      exchange.Date,
+			RecordID:  response.ID(),
+			TargetURI: targetURI,
+		})
+	}
+
+	return nil
+}
+
+func (r *Recorder) responseRecord(exchange *fetcher.Exchange, targetURI, payloadDigest string) (*Record, error) {
+	if r.index != nil && isIndexable(exchange) {
+		if ref, ok := r.index.Lookup(payloadDigest); ok {
+			revisit, err := NewRecord(TypeRevisit, exchange.Date)
+			if err != nil {
+				return nil, err
+			}
+			revisit.Header.Add(FieldTargetURI, targetURI)
+			revisit.Header.Add(FieldProfile, ProfileIdenticalPayloadDigest)
+			revisit.Header.Add(FieldRefersTo, ref.RecordID)
+			revisit.Header.Add(FieldRefersToTargetURI, ref.TargetURI)
+			revisit.Header.Add(FieldRefersToDate, FormatDate(ref.Date))
+			revisit.Header.Add(FieldPayloadDigest, payloadDigest)
+			revisit.Header.Add(FieldContentType, ContentTypeHttpResponse)
+			revisit.Header.Add(FieldTruncated, "length")
+			revisit.Content = responseHeaderBlock(exchange.Response, exchange.Header)
+			return revisit, nil
+		}
+	}
+
+	response, err := NewRecord(TypeResponse, exchange.Date)
+	if err != nil {
+		return nil, err
+	}
+	response.Header.Add(FieldTargetURI, targetURI)
+	response.Header.Add(FieldPayloadDigest, payloadDigest)
+	response.Header.Add(FieldContentType, ContentTypeHttpResponse)
+	switch {
+	case exchange.TooLarge:
+		response.Header.Add(FieldTruncated, "length")
+	case exchange.Truncated:
+		response.Header.Add(FieldTruncated, "unspecified")
+	}
+
+	block := responseHeaderBlock(exchange.Response, exchange.Header)
+	response.Content = append(block, exchange.Body...)
+	return response, nil
+}
+
+func (r *Recorder) metadataBlock(exchange *fetcher.Exchange) []byte {
+	fields := Header{
+		{Name: "fetchTimeMs", Value: strconv.FormatInt(exchange.Duration.Milliseconds(), 10)},
+	}
+	if referer := exchange.Request.Referer(); referer != "" {
+		fields.Add("via", referer)
+	}
+	if r.metadata != nil {
+		fields = append(fields, r.metadata(exchange)...)
+	}
+
+	var buf bytes.Buffer
+	for _, field := range fields {
+		buf.WriteString(field.Name + ": " + field.Value + "\r\n")
+	}
+	return buf.Bytes()
+}
+
+// Empty or partial payloads are never deduplicated.
+func isIndexable(exchange *fetcher.Exchange) bool {
+	return len(exchange.Body) > 0 && !exchange.Truncated
+}
+
+// Serializes the request line and headers, as sent by the fetcher.
+func requestBlock(req *http.Request) []byte {
+	var buf bytes.Buffer
+
+	proto := req.Proto
+	if proto == "" {
+		proto = "HTTP/1.1"
+	}
+	fmt.Fprintf(&buf, "%s %s %s\r\n", req.Method, req.URL.RequestURI(), proto)
+
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+	fmt.Fprintf(&buf, "Host: %s\r\n", host)
+
+	_ = req.Header.Write(&buf)
+	buf.WriteString("\r\n")
+
+	return buf.Bytes()
+}
+
+// Serializes the status line and headers (as received), including the
+// blank line separating them from the payload.
+func responseHeaderBlock(resp *http.Response, header http.Header) []byte {
+	var buf bytes.Buffer
+
+	proto := resp.Proto
+	if proto == "" {
+		proto = "HTTP/1.1"
+	}
+
+	status := resp.Status
+	if status == "" {
+		status = strconv.Itoa(resp.StatusCode) + " " + http.StatusText(resp.StatusCode)
+	}
+	fmt.Fprintf(&buf, "%s %s\r\n", proto, status)
+
+	_ = header.Write(&buf)
+	buf.WriteString("\r\n")
+
+	return buf.Bytes()
+}
+
+var _ fetcher.ExchangeRecorder = (*Recorder)(nil)
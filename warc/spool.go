@@ -0,0 +1,217 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package warc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Spool is the subset of [spooler.Spooler] used to persist WARC files,
+// so that the files are committed atomically into the batch directories.
+type Spool interface {
+	NewWriter(ctx context.Context, fileName string) error
+	WriteChunk(data []byte) error
+	Commit() error
+}
+
+type SpoolWriterConfig struct {
+	// File name prefix, the files are named as `<prefix>-<timestamp>-<serial>.warc.gz`
+	Prefix string
+
+	// Fields of the `warcinfo` record written at the beginning of every file
+	Info Header
+
+	// Rotate to a new file before a capture would take the current file past
+	// this size (in bytes). A capture larger than this size is written alone,
+	// after the `warcinfo` record of the file.
+	//
+	// It must be lower than the spooler's maximum file size, as the file would
+	// be aborted otherwise.
+	MaxFileSize int64
+}
+
+func (c SpoolWriterConfig) Validate() error {
+	if c.Prefix == "" {
+		return errors.New("spool writer config: prefix cannot be empty")
+	}
+	if c.MaxFileSize <= 0 {
+		return errors.New("spool writer config: max file size must be greater than zero")
+	}
+	return nil
+}
+
+// SpoolWriter writes gzip-compressed WARC files through the [Spool],
+// rotating to a new file before the configured size would be exceeded.
+// It's safe for concurrent use.
+type SpoolWriter struct {
+	spool      Spool
+	warcinfoID string
+	config     SpoolWriterConfig
+	written    int64
+	serial     int
+	// Captures written into the current file
+	captures int
+	mu       sync.Mutex
+	open     bool
+}
+
+func NewSpoolWriter(spool Spool, config SpoolWriterConfig) (*SpoolWriter, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &SpoolWriter{
+		spool:  spool,
+		config: config,
+	}, nil
+}
+
+// Writes the records into the current file, all of them are kept in the same file.
+func (w *SpoolWriter) WriteRecords(ctx context.Context, records ...*Record) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !w.open {
+		if err := w.rotate(ctx); err != nil {
+			return err
+		}
+	}
+
+	// The records referring to the `warcinfo` of the file (i.e. not set by the caller)
+	stamped := make([]bool, len(records))
+	for i, record := range records {
+		stamped[i] = record.Header.Get(FieldWarcinfoID) == ""
+	}
+
+	chunks, size, err := w.encode(records, stamped)
+	if err != nil {
+		return err
+	}
+
+	// Rotate first, instead of taking the file past the limit
+	if w.captures > 0 && w.written+size > w.config.MaxFileSize {
+		if err := w.commit(); err != nil {
+			return err
+		}
+		if err := w.rotate(ctx); err != nil {
+			return err
+		}
+		if chunks, _, err = w.encode(records, stamped); err != nil {
+			return err
+		}
+	}
+
+	for _, chunk := range chunks {
+		if err := w.write(chunk); err != nil {
+			return err
+		}
+	}
+	w.captures++
+
+	return nil
+}
+
+// Compresses the records of the capture, the stamped ones refer to the
+// `warcinfo` of the current file.
+func (w *SpoolWriter) encode(records []*Record, stamped []bool) ([][]byte, int64, error) {
+	chunks := make([][]byte, 0, len(records))
+	var size int64
+
+	for i, record := range records {
+		if stamped[i] {
+			record.Header.Set(FieldWarcinfoID, w.warcinfoID)
+		}
+
+		chunk, err := encodeRecord(record)
+		if err != nil {
+			return nil, 0, err
+		}
+		chunks = append(chunks, chunk)
+		size += int64(len(chunk))
+	}
+
+	return chunks, size, nil
+}
+
+// Commits the current file, if any.
+func (w *SpoolWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !w.open {
+		return nil
+	}
+	return w.commit()
+}
+
+func (w *SpoolWriter) rotate(ctx context.Context) error {
+	now := time.Now()
+	w.serial++
+	fileName := fmt.Sprintf("%s-%s-%05d.warc.gz", w.config.Prefix, now.UTC().Format("20060102150405"), w.serial)
+
+	if err := w.spool.NewWriter(ctx, fileName); err != nil {
+		return fmt.Errorf("warc: failed to create spool file: %w", err)
+	}
+	w.open = true
+	w.written = 0
+	w.captures = 0
+
+	warcinfo, err := NewRecord(TypeWarcinfo, now)
+	if err != nil {
+		return err
+	}
+	warcinfo.Header.Add(FieldFilename, fileName)
+	warcinfo.Header.Add(FieldContentType, ContentTypeWarcFields)
+
+	var content bytes.Buffer
+	content.WriteString("software: synapse\r\n")
+	content.WriteString("format: WARC File Format 1.1\r\n")
+	for _, field := range w.config.Info {
+		content.WriteString(field.Name + ": " + field.Value + "\r\n")
+	}
+	warcinfo.Content = content.Bytes()
+
+	chunk, err := encodeRecord(warcinfo)
+	if err != nil {
+		return err
+	}
+	if err := w.write(chunk); err != nil {
+		return err
+	}
+	w.warcinfoID = warcinfo.ID()
+
+	return nil
+}
+
+func (w *SpoolWriter) write(chunk []byte) error {
+	if err := w.spool.WriteChunk(chunk); err != nil {
+		return fmt.Errorf("warc: failed to write spool chunk: %w", err)
+	}
+	w.written += int64(len(chunk))
+	return nil
+}
+
+func encodeRecord(record *Record) ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := NewWriter(&buf, true).WriteRecord(record); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (w *SpoolWriter) commit() error {
+	w.open = false
+	w.warcinfoID = ""
+	if err := w.spool.Commit(); err != nil {
+		return fmt.Errorf("warc: failed to commit spool file: %w", err)
+	}
+	return nil
+}
+
+var _ RecordWriter = (*SpoolWriter)(nil)
@@ -0,0 +1,234 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package warc
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	fetcher "github.com/ritvikos/synapse/fetcher/http"
+	"github.com/ritvikos/synapse/spooler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readAll(t *testing.T, r io.Reader) []*Record {
+	t.Helper()
+
+	reader, err := NewReader(r)
+	require.NoError(t, err, "failed to create reader")
+
+	var records []*Record
+	for {
+		record, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err, "failed to read record")
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestWriterReaderRoundTrip(t *testing.T) {
+	for _, compress := range []bool{false, true} {
+		t.Run(map[bool]string{false: "plain", true: "gzip"}[compress], func(t *testing.T) {
+			var buf bytes.Buffer
+			writer := NewWriter(&buf, compress)
+
+			first, err := NewRecord(TypeResource, time.Now())
+			require.NoError(t, err)
+			first.Header.Add(FieldTargetURI, "https://example.com/")
+			first.Header.Add(FieldContentType, "text/plain")
+			first.Content = []byte("hello\r\n\r\nworld")
+
+			second, err := NewRecord(TypeMetadata, time.Now())
+			require.NoError(t, err)
+			second.Content = []byte{}
+
+			require.NoError(t, writer.WriteRecords(t.Context(), first, second))
+
+			records := readAll(t, &buf)
+			require.Len(t, records, 2)
+
+			assert.Equal(t, TypeResource, records[0].Type())
+			assert.Equal(t, first.ID(), records[0].ID())
+			assert.Equal(t, "https://example.com/", records[0].Header.Get(FieldTargetURI))
+			assert.Equal(t, first.Content, records[0].Content)
+			assert.Equal(t, Digest(first.Content), records[0].Header.Get(FieldBlockDigest))
+
+			assert.Equal(t, TypeMetadata, records[1].Type())
+			assert.Empty(t, records[1].Content)
+		})
+	}
+}
+
+func TestRecorderWithSpooler(t *testing.T) {
+	payload := []byte("<html><body>same payload</body></html>")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		_, _ = gz.Write(payload)
+		_ = gz.Close()
+	}))
+	t.Cleanup(server.Close)
+
+	baseDir := t.TempDir()
+	spool, err := spooler.NewSpooler(spooler.SpoolerConfig{
+		BatchConfig: spooler.BatchConfig{
+			BaseDir:      baseDir,
+			MaxBatchSize: 10 * 1024 * 1024,
+		},
+		FileWriterConfig: spooler.FileWriterConfig{
+			MaxFileSize: 10 * 1024 * 1024,
+		},
+	})
+	require.NoError(t, err, "failed to create spooler")
+
+	writer, err := NewSpoolWriter(spool, SpoolWriterConfig{
+		Prefix:      "test",
+		MaxFileSize: 1,
+	})
+	require.NoError(t, err, "failed to create spool writer")
+
+	recorder := NewRecorder(writer, WithDigestIndex(NewMemoryIndex()))
+	f, err := fetcher.NewHttpFetcher(server.Client(), fetcher.WithExchangeRecorder(recorder))
+	require.NoError(t, err, "failed to create fetcher")
+
+	for _, path := range []string{"/first", "/second"} {
+		resp, err := f.Get(t.Context(), server.URL+path, fetcher.WithHeaders(map[string]string{"Accept-Encoding": "gzip"}))
+		require.NoError(t, err, "failed to fetch")
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		assert.Equal(t, payload, body, "caller must receive the decoded body")
+	}
+	require.NoError(t, writer.Close())
+
+	files, err := filepath.Glob(filepath.Join(baseDir, "batch-*", "test-*.warc.gz"))
+	require.NoError(t, err)
+	require.Len(t, files, 2, "each capture must be rotated into its own file")
+
+	var records []*Record
+	for _, file := range files {
+		data, err := os.ReadFile(file) // #nosec G304
+		require.NoError(t, err)
+		fileRecords := readAll(t, bytes.NewReader(data))
+		require.Len(t, fileRecords, 4)
+		assert.Equal(t, TypeWarcinfo, fileRecords[0].Type())
+		records = append(records, fileRecords...)
+	}
+
+	var response, revisit *Record
+	for _, record := range records {
+		switch record.Type() {
+		case TypeResponse:
+			response = record
+		case TypeRevisit:
+			revisit = record
+		}
+	}
+	require.NotNil(t, response, "missing response record")
+	require.NotNil(t, revisit, "missing revisit record")
+
+	_, rawBody, found := bytes.Cut(response.Content, []byte("\r\n\r\n"))
+	require.True(t, found)
+	assert.Contains(t, string(response.Content), "Content-Encoding: gzip")
+	assert.Equal(t, Digest(rawBody), response.Header.Get(FieldPayloadDigest), "payload digest must cover the raw bytes")
+
+	gz, err := gzip.NewReader(bytes.NewReader(rawBody))
+	require.NoError(t, err, "recorded body must be the compressed payload")
+	decoded, err := io.ReadAll(gz)
+	require.NoError(t, err)
+	assert.Equal(t, payload, decoded)
+
+	assert.Equal(t, response.ID(), revisit.Header.Get(FieldRefersTo))
+	assert.Equal(t, response.Header.Get(FieldPayloadDigest), revisit.Header.Get(FieldPayloadDigest))
+}
+
+func TestSpoolWriterRotation(t *testing.T) {
+	baseDir := t.TempDir()
+	spool, err := spooler.NewSpooler(spooler.SpoolerConfig{
+		BatchConfig: spooler.BatchConfig{
+			BaseDir:      baseDir,
+			MaxBatchSize: 10 * 1024 * 1024,
+		},
+		FileWriterConfig: spooler.FileWriterConfig{
+			MaxFileSize: 3000,
+		},
+	})
+	require.NoError(t, err)
+
+	writer, err := NewSpoolWriter(spool, SpoolWriterConfig{
+		Prefix:      "test",
+		MaxFileSize: 2000,
+	})
+	require.NoError(t, err)
+
+	capture := func(size int) *Record {
+		record, err := NewRecord(TypeResource, time.Now())
+		require.NoError(t, err)
+		record.Header.Add(FieldTargetURI, "https://example.com/")
+		// Incompressible content
+		record.Content = make([]byte, size)
+		_, _ = rand.NewChaCha8([32]byte{byte(size)}).Read(record.Content)
+		return record
+	}
+
+	small, large := capture(500), capture(1800)
+	require.NoError(t, writer.WriteRecords(t.Context(), small))
+	// Larger than the remaining space, but within the limit on its own
+	require.NoError(t, writer.WriteRecords(t.Context(), large))
+	require.NoError(t, writer.Close())
+
+	files, err := filepath.Glob(filepath.Join(baseDir, "batch-*", "test-*.warc.gz"))
+	require.NoError(t, err)
+	require.Len(t, files, 2, "the large capture must be rotated into its own file")
+
+	var ids []string
+	for _, file := range files {
+		data, err := os.ReadFile(file) // #nosec G304
+		require.NoError(t, err)
+
+		records := readAll(t, bytes.NewReader(data))
+		require.Len(t, records, 2)
+		assert.Equal(t, TypeWarcinfo, records[0].Type())
+		assert.Equal(t, records[0].ID(), records[1].Header.Get(FieldWarcinfoID))
+		ids = append(ids, records[1].ID())
+	}
+	assert.ElementsMatch(t, []string{small.ID(), large.ID()}, ids)
+}
+
+func TestRecorderTruncated(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = io.WriteString(w, "longer than the limit")
+	}))
+	t.Cleanup(server.Close)
+
+	var buf bytes.Buffer
+	recorder := NewRecorder(NewWriter(&buf, false))
+	f, err := fetcher.NewHttpFetcher(server.Client(), fetcher.WithExchangeRecorder(recorder), fetcher.WithMaxBodySize(4))
+	require.NoError(t, err)
+
+	resp, err := f.Get(t.Context(), server.URL)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	records := readAll(t, &buf)
+	require.NotEmpty(t, records)
+	assert.Equal(t, TypeResponse, records[0].Type())
+	assert.Equal(t, "length", records[0].Header.Get(FieldTruncated))
+}
@@ -0,0 +1,122 @@
+// Copyright 2025-2026 Ritvik Gupta
+// SPDX-License-Identifier: Apache-2.0
+
+package warc
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"strconv"
+)
+
+// RecordWriter persists WARC records.
+//
+// Records passed in a single call belong to the same capture event,
+// implementations must keep them together (eg: in the same file).
+type RecordWriter interface {
+	WriteRecords(ctx context.Context, records ...*Record) error
+}
+
+// Writer serializes WARC records to the underlying writer.
+//
+// When compression is enabled, each record is written as an individual
+// gzip member, so that the records can be accessed randomly by offset.
+type Writer struct {
+	w        io.Writer
+	compress bool
+}
+
+func NewWriter(w io.Writer, compress bool) *Writer {
+	return &Writer{
+		w:        w,
+		compress: compress,
+	}
+}
+
+// Writes a single record and returns the number of bytes written to the
+// underlying writer (after compression, if enabled).
+//
+// The `Content-Length` field is always derived from the record content,
+// while `WARC-Block-Digest` is computed if not already set.
+func (w *Writer) WriteRecord(record *Record) (int64, error) {
+	if record.Header.Get(FieldType) == "" || record.Header.Get(FieldRecordID) == "" || record.Header.Get(FieldDate) == "" {
+		return 0, errors.New("warc: record header must contain type, record ID and date")
+	}
+
+	record.Header.Set(FieldContentLength, strconv.Itoa(len(record.Content)))
+	if record.Header.Get(FieldBlockDigest) == "" {
+		record.Header.Set(FieldBlockDigest, Digest(record.Content))
+	}
+
+	counter := &countingWriter{w: w.w}
+
+	if !w.compress {
+		if err := writeRecord(counter, record); err != nil {
+			return counter.n, err
+		}
+		return counter.n, nil
+	}
+
+	gz := gzip.NewWriter(counter)
+	if err := writeRecord(gz, record); err != nil {
+		return counter.n, err
+	}
+	if err := gz.Close(); err != nil {
+		return counter.n, err
+	}
+
+	return counter.n, nil
+}
+
+func (w *Writer) WriteRecords(_ context.Context, records ...*Record) error {
+	for _, record := range records {
+		if _, err := w.WriteRecord(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeRecord(w io.Writer, record *Record) error {
+	bw := bufio.NewWriter(w)
+
+	if _, err := bw.WriteString(Version + "\r\n"); err != nil {
+		return err
+	}
+
+	for _, field := range record.Header {
+		if _, err := bw.WriteString(field.Name + ": " + field.Value + "\r\n"); err != nil {
+			return err
+		}
+	}
+
+	if _, err := bw.WriteString("\r\n"); err != nil {
+		return err
+	}
+
+	if _, err := bw.Write(record.Content); err != nil {
+		return err
+	}
+
+	if _, err := bw.WriteString("\r\n\r\n"); err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+var _ RecordWriter = (*Writer)(nil)